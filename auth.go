@@ -0,0 +1,102 @@
+package dhcp6
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+)
+
+// Reconfigure Key Authentication Protocol message types, as defined in
+// RFC 3315, Section 21.5.  They distinguish the two uses of an
+// Authentication option (option code 11) under this protocol: handing a
+// client its Reconfigure key, and authenticating a Reconfigure message
+// with that key.
+const (
+	// ReconfigureKeyTypeValue marks an Authentication option carrying a
+	// Reconfigure key itself, sent by a server to a client in a Reply.
+	ReconfigureKeyTypeValue uint8 = 1
+
+	// ReconfigureKeyTypeHMAC marks an Authentication option carrying an
+	// HMAC-MD5 digest of a Reconfigure message, computed with a
+	// previously issued Reconfigure key.
+	ReconfigureKeyTypeHMAC uint8 = 2
+)
+
+// Fixed fields of an Authentication option under the Reconfigure Key
+// Authentication Protocol, as defined in RFC 3315, Section 21.4.
+const (
+	authProtocolReconfigureKey uint8 = 3
+	authAlgorithmHMACMD5       uint8 = 1
+
+	// reconfigureKeyAuthLen is the length of an Authentication option's
+	// content under the Reconfigure Key Authentication Protocol: 1 byte
+	// protocol, 1 byte algorithm, 1 byte RDM, 8 bytes replay detection,
+	// 1 byte key type, 16 bytes key or digest value.
+	reconfigureKeyAuthLen = 28
+)
+
+// A ReconfigureKeyAuth is an Authentication option (option code 11)
+// carrying the Reconfigure Key Authentication Protocol described in
+// RFC 3315, Sections 21.4 and 21.5.  A server sends one with KeyType
+// ReconfigureKeyTypeValue to hand a client its Reconfigure key in a
+// Reply, and one with KeyType ReconfigureKeyTypeHMAC, keyed with that
+// value, to authenticate a subsequent Reconfigure.
+type ReconfigureKeyAuth struct {
+	KeyType uint8
+	Value   [16]byte
+}
+
+// MarshalBinary allocates a byte slice containing the data from a
+// ReconfigureKeyAuth.
+func (a *ReconfigureKeyAuth) MarshalBinary() ([]byte, error) {
+	b := make([]byte, reconfigureKeyAuthLen)
+	b[0] = authProtocolReconfigureKey
+	b[1] = authAlgorithmHMACMD5
+	// b[2] is RDM and b[3:11] is replay detection, both unused by the
+	// Reconfigure Key Authentication Protocol.
+	b[11] = a.KeyType
+	copy(b[12:], a.Value[:])
+
+	return b, nil
+}
+
+// UnmarshalBinary unmarshals a raw byte slice into a ReconfigureKeyAuth.
+func (a *ReconfigureKeyAuth) UnmarshalBinary(b []byte) error {
+	if len(b) != reconfigureKeyAuthLen {
+		return ErrInvalidOptionVal
+	}
+
+	a.KeyType = b[11]
+	copy(a.Value[:], b[12:28])
+
+	return nil
+}
+
+// GetReconfigureKeyAuth fetches and parses a ReconfigureKeyAuth from an
+// Options map.  If the option is not found, ErrOptionNotPresent is
+// returned.
+func GetReconfigureKeyAuth(options Options) (*ReconfigureKeyAuth, error) {
+	v, err := options.GetOne(OptionAuth)
+	if err != nil {
+		return nil, err
+	}
+
+	a := new(ReconfigureKeyAuth)
+	if err := a.UnmarshalBinary(v); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// HMACReconfigureKey computes the HMAC-MD5 digest of msg keyed by key,
+// as described in RFC 3315, Section 21.5, for use as a
+// ReconfigureKeyAuth's Value when KeyType is ReconfigureKeyTypeHMAC.
+func HMACReconfigureKey(key [16]byte, msg []byte) [16]byte {
+	mac := hmac.New(md5.New, key[:])
+	mac.Write(msg)
+
+	var sum [16]byte
+	copy(sum[:], mac.Sum(nil))
+
+	return sum
+}
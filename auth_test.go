@@ -0,0 +1,54 @@
+package dhcp6
+
+import "testing"
+
+func TestReconfigureKeyAuthMarshalUnmarshal(t *testing.T) {
+	want := &ReconfigureKeyAuth{
+		KeyType: ReconfigureKeyTypeHMAC,
+		Value:   [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+	}
+
+	b, err := want.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+	if len(b) != reconfigureKeyAuthLen {
+		t.Fatalf("MarshalBinary length = %d, want %d", len(b), reconfigureKeyAuthLen)
+	}
+
+	got := new(ReconfigureKeyAuth)
+	if err := got.UnmarshalBinary(b); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+
+	if got.KeyType != want.KeyType {
+		t.Errorf("KeyType: got %d, want %d", got.KeyType, want.KeyType)
+	}
+	if got.Value != want.Value {
+		t.Errorf("Value: got %v, want %v", got.Value, want.Value)
+	}
+}
+
+func TestReconfigureKeyAuthUnmarshalBinaryBadLength(t *testing.T) {
+	a := new(ReconfigureKeyAuth)
+	if err := a.UnmarshalBinary(make([]byte, reconfigureKeyAuthLen-1)); err != ErrInvalidOptionVal {
+		t.Fatalf("UnmarshalBinary(short) = %v, want ErrInvalidOptionVal", err)
+	}
+}
+
+func TestHMACReconfigureKey(t *testing.T) {
+	var keyA, keyB [16]byte
+	keyA[0] = 1
+	keyB[0] = 2
+	msg := []byte("reconfigure")
+
+	sum1 := HMACReconfigureKey(keyA, msg)
+	sum2 := HMACReconfigureKey(keyA, msg)
+	if sum1 != sum2 {
+		t.Errorf("HMACReconfigureKey is not deterministic: %v != %v", sum1, sum2)
+	}
+
+	if sum3 := HMACReconfigureKey(keyB, msg); sum3 == sum1 {
+		t.Errorf("HMACReconfigureKey produced the same digest for different keys")
+	}
+}
@@ -0,0 +1,65 @@
+package dhcp6client
+
+import (
+	"net"
+
+	"github.com/oiooj/dhcp6d"
+	"github.com/oiooj/dhcp6d/dhcp6opts"
+)
+
+// An Advertisement is a DHCPv6 Advertise message received from a
+// server in response to a Solicit.
+type Advertisement struct {
+	// TransactionID is the transaction ID echoed back by the server.
+	TransactionID dhcp6.TransactionID
+
+	// Options is the set of options the server included in its
+	// Advertise.
+	Options dhcp6.Options
+
+	// ServerAddr is the address the Advertise was received from.
+	ServerAddr net.Addr
+}
+
+// UnicastAddr returns the server address learned from adv's Server
+// Unicast option (RFC 3315, Section 22.12), or nil if adv did not
+// include one.
+func (a *Advertisement) UnicastAddr() net.Addr {
+	return unicastAddr(a.Options)
+}
+
+// Preference returns the Advertisement's Preference option value, as
+// defined in RFC 3315, Section 22.8, or 0 if it did not include one.
+func (a *Advertisement) Preference() dhcp6opts.Preference {
+	v, err := a.Options.GetOne(dhcp6.OptionPreference)
+	if err != nil {
+		return 0
+	}
+
+	var p dhcp6opts.Preference
+	if err := p.UnmarshalBinary(v); err != nil {
+		return 0
+	}
+
+	return p
+}
+
+// RequestIANAFrom extracts every IANA option from adv, for inclusion in
+// a subsequent Request, as required by RFC 3315, Section 18.1.1.
+func RequestIANAFrom(adv *Advertisement) ([]*dhcp6opts.IANA, error) {
+	ianas, err := dhcp6opts.GetIANA(adv.Options)
+	if err == dhcp6.ErrOptionNotPresent {
+		return nil, nil
+	}
+	return ianas, err
+}
+
+// RequestIAPDFrom extracts every IAPD option from adv, for inclusion in
+// a subsequent Request, as required by RFC 3633, Section 12.2.
+func RequestIAPDFrom(adv *Advertisement) ([]*dhcp6opts.IAPD, error) {
+	iapds, err := dhcp6opts.GetIAPD(adv.Options)
+	if err == dhcp6.ErrOptionNotPresent {
+		return nil, nil
+	}
+	return iapds, err
+}
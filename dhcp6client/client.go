@@ -0,0 +1,218 @@
+package dhcp6client
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/oiooj/dhcp6d"
+	"github.com/oiooj/dhcp6d/dhcp6opts"
+	"golang.org/x/net/ipv6"
+)
+
+// clientPort and serverPort are the UDP ports used by DHCPv6 clients
+// and servers/relays, as defined in RFC 3315, Section 5.2.
+const (
+	clientPort = 546
+	serverPort = 547
+)
+
+// allDHCPRelayAgentsAndServers is the link-local multicast address
+// DHCPv6 clients send requests to, as defined in RFC 3315, Section 5.1.
+var allDHCPRelayAgentsAndServers = &net.UDPAddr{
+	IP:   net.ParseIP("ff02::1:2"),
+	Port: serverPort,
+}
+
+// unicastAddr extracts the address granted by the Server Unicast
+// option (RFC 3315, Section 22.12) from options, or returns nil if
+// options did not include one. Absent a learned unicast address, RFC
+// 3315, Section 18.1.1 requires a client to send via
+// allDHCPRelayAgentsAndServers instead.
+func unicastAddr(options dhcp6.Options) net.Addr {
+	v, err := options.GetOne(dhcp6.OptionUnicast)
+	if err != nil {
+		return nil
+	}
+
+	var u dhcp6opts.Unicast
+	if err := u.UnmarshalBinary(v); err != nil {
+		return nil
+	}
+
+	return &net.UDPAddr{IP: net.IP(u), Port: serverPort}
+}
+
+// readTimeout bounds a single read while waiting for a response within
+// a retransmission window.
+const readTimeout = 50 * time.Millisecond
+
+// A Client implements the client side of DHCPv6, as defined in RFC
+// 3315.  A Client is not safe for concurrent use by multiple
+// goroutines.
+type Client struct {
+	// Iface is the name of the network interface the Client sends and
+	// receives DHCPv6 messages on.
+	Iface string
+
+	// Raw, if true, sends and receives DHCPv6 messages over a raw,
+	// link-layer socket instead of a UDP socket, for use before Iface
+	// has an IPv6 address configured, such as during netboot.  Raw mode
+	// is only implemented on Linux.
+	Raw bool
+
+	// PrefixLength, if non-zero, instructs Solicit and Request to also
+	// include an IA_PD option requesting a delegated prefix of this
+	// length, as defined in RFC 3633.  A Client requests at most one
+	// IA_NA and one IA_PD per exchange.
+	PrefixLength uint8
+
+	ifi  *net.Interface
+	duid []byte
+	iaid [4]byte
+	conn net.PacketConn
+}
+
+// NewClient creates a Client which sends and receives DHCPv6 messages
+// on the network interface named iface.
+func NewClient(iface string) (*Client, error) {
+	ifi, err := net.InterfaceByName(iface)
+	if err != nil {
+		return nil, err
+	}
+
+	duid := dhcp6opts.NewDUIDLL(1, ifi.HardwareAddr)
+	b, err := duid.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{Iface: iface, ifi: ifi, duid: b}, nil
+}
+
+// Close closes c's underlying socket.
+func (c *Client) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn.Close()
+}
+
+// dial opens c's underlying socket, if it has not been opened already.
+func (c *Client) dial() (net.PacketConn, error) {
+	if c.conn != nil {
+		return c.conn, nil
+	}
+
+	if c.Raw {
+		conn, err := newRawConn(c.ifi)
+		if err != nil {
+			return nil, err
+		}
+		c.conn = conn
+		return c.conn, nil
+	}
+
+	pc, err := net.ListenPacket("udp6", fmt.Sprintf("[::]:%d", clientPort))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ipv6.NewPacketConn(pc).SetMulticastInterface(c.ifi); err != nil {
+		pc.Close()
+		return nil, err
+	}
+
+	c.conn = pc
+	return c.conn, nil
+}
+
+// send marshals p and writes it to dst on c's underlying socket.
+func (c *Client) send(p *dhcp6.Packet, dst net.Addr) error {
+	conn, err := c.dial()
+	if err != nil {
+		return err
+	}
+
+	b, err := p.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.WriteTo(b, dst)
+	return err
+}
+
+// recv waits up to timeout for a Packet whose TransactionID matches
+// txID, discarding anything else it reads in the meantime.  It returns
+// nil, nil on timeout, and ctx.Err() promptly if ctx is canceled,
+// rather than blocking for the full timeout.
+func (c *Client) recv(ctx context.Context, txID dhcp6.TransactionID, timeout time.Duration) (*dhcp6.Packet, net.Addr, error) {
+	conn, err := c.dial()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	deadline := time.Now().Add(timeout)
+	buf := make([]byte, 1500)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		default:
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return nil, nil, nil
+		}
+		if remaining > readTimeout {
+			remaining = readTimeout
+		}
+
+		if err := conn.SetReadDeadline(time.Now().Add(remaining)); err != nil {
+			return nil, nil, err
+		}
+
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+				if time.Now().After(deadline) {
+					return nil, nil, nil
+				}
+				continue
+			}
+			return nil, nil, err
+		}
+
+		p := new(dhcp6.Packet)
+		if err := p.UnmarshalBinary(buf[:n]); err != nil {
+			continue
+		}
+		if p.TransactionID != txID {
+			continue
+		}
+
+		return p, addr, nil
+	}
+}
+
+// newTransactionID generates a random TransactionID, as required by RFC
+// 3315, Section 15.
+func newTransactionID() (dhcp6.TransactionID, error) {
+	var txID dhcp6.TransactionID
+	_, err := rand.Read(txID[:])
+	return txID, err
+}
+
+// nextIAID derives a stable IAID for ifi, as suggested by RFC 3315,
+// Section 12.1.
+func nextIAID(ifi *net.Interface) [4]byte {
+	var iaid [4]byte
+	iaid[2] = byte(ifi.Index >> 8)
+	iaid[3] = byte(ifi.Index)
+	return iaid
+}
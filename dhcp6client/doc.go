@@ -0,0 +1,4 @@
+// Package dhcp6client implements the client side of DHCPv6, as defined
+// in RFC 3315, on top of the wire types in package dhcp6 and the
+// options in package dhcp6opts.
+package dhcp6client
@@ -0,0 +1,118 @@
+package dhcp6client
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/oiooj/dhcp6d"
+	"github.com/oiooj/dhcp6d/dhcp6opts"
+)
+
+// A Lease is the result of a successful Client.Obtain call: the
+// addresses and/or delegated prefixes a server has assigned, along with
+// the times at which they should be renewed or rebound.
+type Lease struct {
+	// ServerID is the raw DUID of the server which issued this Lease.
+	ServerID []byte
+
+	// Addrs and Prefixes are the IA_NA addresses and IA_PD prefixes
+	// assigned by the server.
+	Addrs    []*dhcp6opts.IAAddr
+	Prefixes []*dhcp6opts.IAPrefix
+
+	// T1 and T2 are the smallest T1/T2 values across every IANA/IAPD in
+	// the Reply, used to schedule Client.Renew and Client.Rebind.
+	T1, T2 time.Duration
+
+	// Obtained is the time the Lease was obtained, used together with
+	// T1/T2 to compute RenewAt and RebindAt.
+	Obtained time.Time
+
+	// serverAddr is the address learned from the granting Reply's
+	// Server Unicast option, or nil if it did not include one.
+	serverAddr net.Addr
+}
+
+// RenewAt returns the time at which l should be renewed via
+// Client.Renew.
+func (l *Lease) RenewAt() time.Time {
+	return l.Obtained.Add(l.T1)
+}
+
+// RebindAt returns the time at which l should be rebound via
+// Client.Rebind.
+func (l *Lease) RebindAt() time.Time {
+	return l.Obtained.Add(l.T2)
+}
+
+// Obtain runs a full Solicit -> Request exchange, selecting the best
+// Advertise by Preference, and returns the resulting Lease.
+func (c *Client) Obtain(ctx context.Context) (*Lease, error) {
+	adv, err := c.Solicit(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if adv == nil {
+		return nil, ErrTimeout
+	}
+
+	reply, err := c.Request(ctx, adv)
+	if err != nil {
+		return nil, err
+	}
+
+	return newLease(reply)
+}
+
+// newLease builds a Lease from a Reply's IANA and IAPD options.
+func newLease(reply *Reply) (*Lease, error) {
+	serverID, err := reply.Options.GetOne(dhcp6.OptionServerID)
+	if err != nil {
+		return nil, err
+	}
+
+	lease := &Lease{
+		ServerID:   serverID,
+		Obtained:   time.Now(),
+		serverAddr: reply.UnicastAddr(),
+	}
+
+	ianas, err := dhcp6opts.GetIANA(reply.Options)
+	if err != nil && err != dhcp6.ErrOptionNotPresent {
+		return nil, err
+	}
+	for _, ia := range ianas {
+		addrs, err := dhcp6opts.GetIAAddr(ia.Options)
+		if err != nil && err != dhcp6.ErrOptionNotPresent {
+			return nil, err
+		}
+		lease.Addrs = append(lease.Addrs, addrs...)
+		lease.mergeTimers(ia.T1, ia.T2)
+	}
+
+	iapds, err := dhcp6opts.GetIAPD(reply.Options)
+	if err != nil && err != dhcp6.ErrOptionNotPresent {
+		return nil, err
+	}
+	for _, iapd := range iapds {
+		prefixes, err := dhcp6opts.GetIAPrefix(iapd.Options)
+		if err != nil && err != dhcp6.ErrOptionNotPresent {
+			return nil, err
+		}
+		lease.Prefixes = append(lease.Prefixes, prefixes...)
+		lease.mergeTimers(iapd.T1, iapd.T2)
+	}
+
+	return lease, nil
+}
+
+// mergeTimers narrows l's T1/T2 to the smallest values seen so far.
+func (l *Lease) mergeTimers(t1, t2 time.Duration) {
+	if l.T1 == 0 || t1 < l.T1 {
+		l.T1 = t1
+	}
+	if l.T2 == 0 || t2 < l.T2 {
+		l.T2 = t2
+	}
+}
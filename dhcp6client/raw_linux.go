@@ -0,0 +1,215 @@
+//go:build linux
+// +build linux
+
+package dhcp6client
+
+import (
+	"encoding/binary"
+	"errors"
+	"net"
+	"syscall"
+	"time"
+)
+
+// etherTypeIPv6 is the EtherType of an IPv6 frame, as defined in RFC
+// 2464, Section 3.
+const etherTypeIPv6 = 0x86DD
+
+// rawConn is a net.PacketConn which sends and receives DHCPv6 messages
+// directly over a link-layer socket, for use before Iface has an IPv6
+// address configured.  Since the kernel will not build an IPv6 packet
+// without a configured source address, rawConn builds and parses the
+// IPv6 and UDP headers DHCPv6 is normally carried in by hand.
+type rawConn struct {
+	fd  int
+	ifi *net.Interface
+}
+
+// newRawConn opens a raw, link-layer socket bound to ifi.
+func newRawConn(ifi *net.Interface) (net.PacketConn, error) {
+	fd, err := syscall.Socket(syscall.AF_PACKET, syscall.SOCK_DGRAM, int(htons(etherTypeIPv6)))
+	if err != nil {
+		return nil, err
+	}
+
+	addr := syscall.SockaddrLinklayer{
+		Protocol: htons(etherTypeIPv6),
+		Ifindex:  ifi.Index,
+	}
+	if err := syscall.Bind(fd, &addr); err != nil {
+		syscall.Close(fd)
+		return nil, err
+	}
+
+	return &rawConn{fd: fd, ifi: ifi}, nil
+}
+
+// htons converts a uint16 from host to network byte order.
+func htons(v uint16) uint16 {
+	return (v << 8) | (v >> 8)
+}
+
+func (c *rawConn) Close() error {
+	return syscall.Close(c.fd)
+}
+
+func (c *rawConn) LocalAddr() net.Addr {
+	return &net.UDPAddr{IP: net.IPv6unspecified, Port: clientPort}
+}
+
+func (c *rawConn) SetDeadline(t time.Time) error {
+	if err := c.SetReadDeadline(t); err != nil {
+		return err
+	}
+	return c.SetWriteDeadline(t)
+}
+
+func (c *rawConn) SetReadDeadline(t time.Time) error {
+	return syscall.SetsockoptTimeval(c.fd, syscall.SOL_SOCKET, syscall.SO_RCVTIMEO, durationToTimeval(time.Until(t)))
+}
+
+func (c *rawConn) SetWriteDeadline(t time.Time) error {
+	return syscall.SetsockoptTimeval(c.fd, syscall.SOL_SOCKET, syscall.SO_SNDTIMEO, durationToTimeval(time.Until(t)))
+}
+
+// durationToTimeval converts d into a syscall.Timeval, clamping
+// negative durations to zero (meaning "do not block").
+func durationToTimeval(d time.Duration) *syscall.Timeval {
+	if d < 0 {
+		d = 0
+	}
+	return &syscall.Timeval{
+		Sec:  int64(d / time.Second),
+		Usec: int64((d % time.Second) / time.Microsecond),
+	}
+}
+
+// WriteTo builds an IPv6/UDP datagram carrying p and sends it as a
+// single link-layer frame to addr.
+func (c *rawConn) WriteTo(p []byte, addr net.Addr) (int, error) {
+	udpAddr, ok := addr.(*net.UDPAddr)
+	if !ok {
+		return 0, errors.New("dhcp6client: raw socket requires a *net.UDPAddr destination")
+	}
+
+	datagram := buildIPv6UDP(net.IPv6unspecified, udpAddr.IP, clientPort, udpAddr.Port, p)
+
+	dst := syscall.SockaddrLinklayer{
+		Protocol: htons(etherTypeIPv6),
+		Ifindex:  c.ifi.Index,
+		Halen:    6,
+	}
+	copy(dst.Addr[:6], multicastMAC(udpAddr.IP))
+
+	if err := syscall.Sendto(c.fd, datagram, 0, &dst); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// ReadFrom reads link-layer frames until one carries an IPv6/UDP
+// datagram from serverPort, skipping any other frame, and returns that
+// datagram's UDP payload.
+func (c *rawConn) ReadFrom(p []byte) (int, net.Addr, error) {
+	buf := make([]byte, 1500)
+	for {
+		n, _, err := syscall.Recvfrom(c.fd, buf, 0)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		srcIP, srcPort, payload, err := parseIPv6UDP(buf[:n])
+		if err != nil || srcPort != serverPort {
+			continue
+		}
+
+		copied := copy(p, payload)
+		return copied, &net.UDPAddr{IP: srcIP, Port: srcPort}, nil
+	}
+}
+
+// multicastMAC returns the Ethernet multicast address corresponding to
+// the IPv6 multicast address ip, as defined in RFC 2464, Section 7.
+func multicastMAC(ip net.IP) net.HardwareAddr {
+	ip16 := ip.To16()
+	return net.HardwareAddr{0x33, 0x33, ip16[12], ip16[13], ip16[14], ip16[15]}
+}
+
+// buildIPv6UDP constructs a raw IPv6 packet carrying a UDP datagram
+// between src/srcPort and dst/dstPort.
+func buildIPv6UDP(src, dst net.IP, srcPort, dstPort int, payload []byte) []byte {
+	udpLen := 8 + len(payload)
+	b := make([]byte, 40+udpLen)
+
+	b[0] = 0x60 // version 6, no traffic class or flow label
+	binary.BigEndian.PutUint16(b[4:6], uint16(udpLen))
+	b[6] = 17 // next header: UDP
+	b[7] = 64 // hop limit
+	copy(b[8:24], src.To16())
+	copy(b[24:40], dst.To16())
+
+	u := b[40:]
+	binary.BigEndian.PutUint16(u[0:2], uint16(srcPort))
+	binary.BigEndian.PutUint16(u[2:4], uint16(dstPort))
+	binary.BigEndian.PutUint16(u[4:6], uint16(udpLen))
+	copy(u[8:], payload)
+	binary.BigEndian.PutUint16(u[6:8], udpChecksum(src, dst, u))
+
+	return b
+}
+
+// parseIPv6UDP parses a raw IPv6 packet carrying a UDP datagram,
+// returning its source address, source port, and payload.
+func parseIPv6UDP(b []byte) (net.IP, int, []byte, error) {
+	if len(b) < 48 || b[6] != 17 {
+		return nil, 0, nil, errors.New("dhcp6client: not an IPv6/UDP packet")
+	}
+
+	src := net.IP(append([]byte(nil), b[8:24]...))
+
+	u := b[40:]
+	srcPort := int(binary.BigEndian.Uint16(u[0:2]))
+	udpLen := int(binary.BigEndian.Uint16(u[4:6]))
+	if udpLen < 8 || 40+udpLen > len(b) {
+		return nil, 0, nil, errors.New("dhcp6client: truncated UDP datagram")
+	}
+
+	return src, srcPort, b[48 : 40+udpLen], nil
+}
+
+// udpChecksum computes the UDP checksum over src/dst's IPv6
+// pseudo-header and u's UDP header and payload, as defined in RFC 2460,
+// Section 8.1.
+func udpChecksum(src, dst net.IP, u []byte) uint16 {
+	var sum uint32
+
+	add := func(b []byte) {
+		for i := 0; i+1 < len(b); i += 2 {
+			sum += uint32(binary.BigEndian.Uint16(b[i : i+2]))
+		}
+		if len(b)%2 == 1 {
+			sum += uint32(b[len(b)-1]) << 8
+		}
+	}
+
+	add(src.To16())
+	add(dst.To16())
+
+	var lenAndProto [8]byte
+	binary.BigEndian.PutUint32(lenAndProto[0:4], uint32(len(u)))
+	lenAndProto[7] = 17
+	add(lenAndProto[:])
+
+	add(u)
+
+	for sum>>16 != 0 {
+		sum = (sum & 0xFFFF) + (sum >> 16)
+	}
+
+	checksum := ^uint16(sum)
+	if checksum == 0 {
+		checksum = 0xFFFF
+	}
+	return checksum
+}
@@ -0,0 +1,56 @@
+//go:build linux
+// +build linux
+
+package dhcp6client
+
+import (
+	"bytes"
+	"net"
+	"testing"
+)
+
+func TestBuildParseIPv6UDP(t *testing.T) {
+	src := net.ParseIP("2001:db8::1")
+	dst := net.ParseIP("2001:db8::2")
+	payload := []byte("dhcpv6 payload")
+
+	datagram := buildIPv6UDP(src, dst, clientPort, serverPort, payload)
+
+	gotSrc, gotPort, gotPayload, err := parseIPv6UDP(datagram)
+	if err != nil {
+		t.Fatalf("parseIPv6UDP: %v", err)
+	}
+
+	if !gotSrc.Equal(src) {
+		t.Errorf("src: got %v, want %v", gotSrc, src)
+	}
+	if gotPort != clientPort {
+		t.Errorf("srcPort: got %d, want %d", gotPort, clientPort)
+	}
+	if !bytes.Equal(gotPayload, payload) {
+		t.Errorf("payload: got %q, want %q", gotPayload, payload)
+	}
+}
+
+func TestUDPChecksumVariesWithPayload(t *testing.T) {
+	src := net.ParseIP("2001:db8::1")
+	dst := net.ParseIP("2001:db8::2")
+
+	a := buildIPv6UDP(src, dst, clientPort, serverPort, []byte("payload a"))
+	b := buildIPv6UDP(src, dst, clientPort, serverPort, []byte("payload b"))
+
+	checksumOf := func(datagram []byte) uint16 {
+		u := datagram[40:]
+		return uint16(u[6])<<8 | uint16(u[7])
+	}
+
+	if checksumOf(a) == checksumOf(b) {
+		t.Error("udpChecksum produced the same checksum for different payloads")
+	}
+}
+
+func TestParseIPv6UDPTruncated(t *testing.T) {
+	if _, _, _, err := parseIPv6UDP(make([]byte, 10)); err == nil {
+		t.Fatal("parseIPv6UDP(10 bytes) = nil error, want error")
+	}
+}
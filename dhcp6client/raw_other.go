@@ -0,0 +1,15 @@
+//go:build !linux
+// +build !linux
+
+package dhcp6client
+
+import (
+	"errors"
+	"net"
+)
+
+// newRawConn returns an error on platforms other than Linux, where
+// Client's raw-socket mode is not implemented.
+func newRawConn(ifi *net.Interface) (net.PacketConn, error) {
+	return nil, errors.New("dhcp6client: raw socket mode is only implemented on Linux")
+}
@@ -0,0 +1,87 @@
+package dhcp6client
+
+import (
+	"context"
+	"net"
+
+	"github.com/oiooj/dhcp6d"
+	"github.com/oiooj/dhcp6d/dhcp6opts"
+)
+
+// Renew sends a Renew message for lease's IANA/IAPD bindings, following
+// the RFC 3315, Section 14 retransmission algorithm with the
+// REN_TIMEOUT/REN_MAX_RT parameters. The message is sent to
+// allDHCPRelayAgentsAndServers, as required by RFC 3315, Section
+// 18.1.1, unless the Reply which granted lease carried a Server
+// Unicast option permitting direct unicast to the server.
+func (c *Client) Renew(ctx context.Context, lease *Lease) (*Reply, error) {
+	dst := net.Addr(allDHCPRelayAgentsAndServers)
+	if lease.serverAddr != nil {
+		dst = lease.serverAddr
+	}
+	return c.renewOrRebind(ctx, lease, dhcp6.MessageTypeRenew, dst, renewParams)
+}
+
+// Rebind sends a Rebind message for lease's IANA/IAPD bindings to the
+// All_DHCP_Relay_Agents_and_Servers multicast address, for use when the
+// server which issued them has not responded to Renew, following the
+// RFC 3315, Section 14 retransmission algorithm with the
+// REB_TIMEOUT/REB_MAX_RT parameters.
+func (c *Client) Rebind(ctx context.Context, lease *Lease) (*Reply, error) {
+	return c.renewOrRebind(ctx, lease, dhcp6.MessageTypeRebind, allDHCPRelayAgentsAndServers, rebindParams)
+}
+
+// renewOrRebind sends a Renew or Rebind built from lease's bindings to
+// dst, and waits for a Reply.
+func (c *Client) renewOrRebind(ctx context.Context, lease *Lease, mt dhcp6.MessageType, dst net.Addr, params retransmitParams) (*Reply, error) {
+	txID, err := newTransactionID()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := make(dhcp6.Options)
+	opts.AddRaw(dhcp6.OptionClientID, c.duid)
+	if mt == dhcp6.MessageTypeRenew {
+		opts.AddRaw(dhcp6.OptionServerID, lease.ServerID)
+	}
+
+	if len(lease.Addrs) > 0 {
+		iaOpts := make(dhcp6.Options)
+		for _, a := range lease.Addrs {
+			if err := iaOpts.Add(dhcp6.OptionIAAddr, a); err != nil {
+				return nil, err
+			}
+		}
+		iana, err := dhcp6opts.NewIANA(c.ensureIAID(), 0, 0, iaOpts)
+		if err != nil {
+			return nil, err
+		}
+		if err := opts.Add(dhcp6.OptionIANA, iana); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(lease.Prefixes) > 0 {
+		pdOpts := make(dhcp6.Options)
+		for _, prefix := range lease.Prefixes {
+			if err := pdOpts.Add(dhcp6.OptionIAPrefix, prefix); err != nil {
+				return nil, err
+			}
+		}
+		iapd, err := dhcp6opts.NewIAPD(c.ensureIAID(), 0, 0, pdOpts)
+		if err != nil {
+			return nil, err
+		}
+		if err := opts.Add(dhcp6.OptionIAPD, iapd); err != nil {
+			return nil, err
+		}
+	}
+
+	p := &dhcp6.Packet{
+		MessageType:   mt,
+		TransactionID: txID,
+		Options:       opts,
+	}
+
+	return c.sendAndWaitForReply(ctx, p, dst, params)
+}
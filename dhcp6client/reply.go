@@ -0,0 +1,24 @@
+package dhcp6client
+
+import (
+	"net"
+
+	"github.com/oiooj/dhcp6d"
+)
+
+// A Reply is a DHCPv6 Reply message received from a server in response
+// to a Request, Renew, Rebind, Release, or Confirm.
+type Reply struct {
+	// TransactionID is the transaction ID echoed back by the server.
+	TransactionID dhcp6.TransactionID
+
+	// Options is the set of options the server included in its Reply.
+	Options dhcp6.Options
+}
+
+// UnicastAddr returns the server address learned from r's Server
+// Unicast option (RFC 3315, Section 22.12), or nil if r did not
+// include one.
+func (r *Reply) UnicastAddr() net.Addr {
+	return unicastAddr(r.Options)
+}
@@ -0,0 +1,103 @@
+package dhcp6client
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/oiooj/dhcp6d"
+)
+
+// Request sends a Request message built from adv and waits for a Reply,
+// following the RFC 3315, Section 14 retransmission algorithm with the
+// REQ_TIMEOUT/REQ_MAX_RT/REQ_MAX_RC parameters. The message is sent to
+// allDHCPRelayAgentsAndServers, as required by RFC 3315, Section
+// 18.1.1, unless adv carried a Server Unicast option granting
+// permission to unicast directly to the server.
+func (c *Client) Request(ctx context.Context, adv *Advertisement) (*Reply, error) {
+	txID, err := newTransactionID()
+	if err != nil {
+		return nil, err
+	}
+
+	serverID, err := adv.Options.GetOne(dhcp6.OptionServerID)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err := c.baseOptions()
+	if err != nil {
+		return nil, err
+	}
+	opts.AddRaw(dhcp6.OptionServerID, serverID)
+
+	ianas, err := RequestIANAFrom(adv)
+	if err != nil {
+		return nil, err
+	}
+	for _, ia := range ianas {
+		if err := opts.Add(dhcp6.OptionIANA, ia); err != nil {
+			return nil, err
+		}
+	}
+
+	iapds, err := RequestIAPDFrom(adv)
+	if err != nil {
+		return nil, err
+	}
+	for _, ipd := range iapds {
+		if err := opts.Add(dhcp6.OptionIAPD, ipd); err != nil {
+			return nil, err
+		}
+	}
+
+	p := &dhcp6.Packet{
+		MessageType:   dhcp6.MessageTypeRequest,
+		TransactionID: txID,
+		Options:       opts,
+	}
+
+	dst := net.Addr(allDHCPRelayAgentsAndServers)
+	if ua := adv.UnicastAddr(); ua != nil {
+		dst = ua
+	}
+
+	return c.sendAndWaitForReply(ctx, p, dst, requestParams)
+}
+
+// sendAndWaitForReply retransmits p to dst according to params until it
+// receives a matching Reply, or params' limits are reached.
+func (c *Client) sendAndWaitForReply(ctx context.Context, p *dhcp6.Packet, dst net.Addr, params retransmitParams) (*Reply, error) {
+	var reply *Reply
+
+	err := retransmit(params, func(rt time.Duration) (bool, error) {
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		default:
+		}
+
+		if err := c.send(p, dst); err != nil {
+			return false, err
+		}
+
+		resp, _, err := c.recv(ctx, p.TransactionID, rt)
+		if err != nil {
+			return false, err
+		}
+		if resp == nil {
+			return false, nil
+		}
+		if resp.MessageType != dhcp6.MessageTypeReply {
+			return false, nil
+		}
+
+		reply = &Reply{TransactionID: resp.TransactionID, Options: resp.Options}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reply, nil
+}
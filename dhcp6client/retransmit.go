@@ -0,0 +1,96 @@
+package dhcp6client
+
+import (
+	"errors"
+	"math/rand"
+	"time"
+)
+
+// ErrTimeout is returned by a Client method when it gives up
+// retransmitting a message because the message's retransmission count
+// or duration limit was reached without a usable response.
+var ErrTimeout = errors.New("dhcp6client: timed out waiting for a response")
+
+// retransmitParams holds the RFC 3315, Section 14 timing parameters
+// used to retransmit a single message type until a matching response is
+// received, or the message's retransmission limits are reached.
+type retransmitParams struct {
+	// InitialTimeout (IRT) is the starting retransmission timeout.
+	InitialTimeout time.Duration
+
+	// MaxTimeout (MRT) caps the exponentially backed off retransmission
+	// timeout.  Zero means unbounded.
+	MaxTimeout time.Duration
+
+	// MaxCount (MRC) caps the number of messages transmitted,
+	// including the first.  Zero means unbounded.
+	MaxCount int
+
+	// MaxDuration (MRD) caps the total time spent retransmitting.  Zero
+	// means unbounded.
+	MaxDuration time.Duration
+}
+
+// RFC 3315, Section 5.5 retransmission parameters for each message type
+// a Client sends.
+var (
+	solicitParams = retransmitParams{InitialTimeout: 1 * time.Second, MaxTimeout: 120 * time.Second}
+	requestParams = retransmitParams{InitialTimeout: 1 * time.Second, MaxTimeout: 30 * time.Second, MaxCount: 10}
+	renewParams   = retransmitParams{InitialTimeout: 10 * time.Second, MaxTimeout: 600 * time.Second}
+	rebindParams  = retransmitParams{InitialTimeout: 10 * time.Second, MaxTimeout: 600 * time.Second}
+)
+
+// retransmit calls attempt once per retransmission, following the RFC
+// 3315, Section 14 algorithm for choosing a retransmission timeout: the
+// first RT is InitialTimeout randomized by +/-10%, and each subsequent
+// RT is roughly double the previous one, also randomized, and capped at
+// MaxTimeout.  attempt is passed the RT it should wait for a response
+// before returning false, nil to be retried; it returns true, nil once
+// it has a usable response, or a non-nil error to abort immediately.
+func retransmit(params retransmitParams, attempt func(rt time.Duration) (bool, error)) error {
+	start := time.Now()
+	rt := jitter(params.InitialTimeout)
+
+	for count := 1; ; count++ {
+		if params.MaxDuration > 0 && time.Since(start) >= params.MaxDuration {
+			return ErrTimeout
+		}
+
+		done, err := attempt(rt)
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+
+		if params.MaxCount > 0 && count >= params.MaxCount {
+			return ErrTimeout
+		}
+
+		rt = nextRT(rt, params.MaxTimeout)
+	}
+}
+
+// randFactor returns a uniform random factor in [-0.1, 0.1], as used
+// by RFC 3315, Section 14's RT computations.
+func randFactor() float64 {
+	return -0.1 + rand.Float64()*0.2
+}
+
+// jitter randomizes d by a uniform factor in [-0.1, 0.1], as used by
+// RFC 3315, Section 14 to compute RT from IRT or MRT.
+func jitter(d time.Duration) time.Duration {
+	return d + time.Duration(float64(d)*randFactor())
+}
+
+// nextRT computes the next retransmission timeout from the previous
+// one, as RT = 2*RTprev + RAND*RTprev, RAND in [-0.1, 0.1] (RFC 3315,
+// Section 14), then caps the result at mrt if mrt is non-zero.
+func nextRT(prev, mrt time.Duration) time.Duration {
+	rt := 2*prev + time.Duration(float64(prev)*randFactor())
+	if mrt > 0 && rt > mrt {
+		return jitter(mrt)
+	}
+	return rt
+}
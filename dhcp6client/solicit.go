@@ -0,0 +1,143 @@
+package dhcp6client
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/oiooj/dhcp6d"
+	"github.com/oiooj/dhcp6d/dhcp6opts"
+)
+
+// Solicit sends a Solicit message and waits for an Advertise, following
+// the RFC 3315, Section 14 retransmission algorithm with the
+// SOL_TIMEOUT/SOL_MAX_RT parameters.  Among the Advertise messages
+// received, it returns the one with the highest Preference value,
+// short-circuiting as soon as one with Preference 255 is seen, as
+// described in RFC 3315, Section 17.1.2.
+func (c *Client) Solicit(ctx context.Context) (*Advertisement, error) {
+	txID, err := newTransactionID()
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err := c.solicitOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	p := &dhcp6.Packet{
+		MessageType:   dhcp6.MessageTypeSolicit,
+		TransactionID: txID,
+		Options:       opts,
+	}
+
+	var best *Advertisement
+	err = retransmit(solicitParams, func(rt time.Duration) (bool, error) {
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		default:
+		}
+
+		if err := c.send(p, allDHCPRelayAgentsAndServers); err != nil {
+			return false, err
+		}
+
+		deadline := time.Now().Add(rt)
+		for time.Now().Before(deadline) {
+			resp, addr, err := c.recv(ctx, txID, time.Until(deadline))
+			if err != nil {
+				return false, err
+			}
+			if resp == nil {
+				break
+			}
+			if resp.MessageType != dhcp6.MessageTypeAdvertise {
+				continue
+			}
+
+			adv := &Advertisement{
+				TransactionID: resp.TransactionID,
+				Options:       resp.Options,
+				ServerAddr:    addr,
+			}
+
+			if best == nil || adv.Preference() > best.Preference() {
+				best = adv
+			}
+			if adv.Preference() == 255 {
+				return true, nil
+			}
+		}
+
+		return best != nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return best, nil
+}
+
+// ensureIAID lazily computes and caches c's IAID.
+func (c *Client) ensureIAID() [4]byte {
+	if c.iaid == [4]byte{} {
+		c.iaid = nextIAID(c.ifi)
+	}
+	return c.iaid
+}
+
+// baseOptions builds the ClientID and ORO options common to every
+// message c sends.
+func (c *Client) baseOptions() (dhcp6.Options, error) {
+	opts := make(dhcp6.Options)
+	opts.AddRaw(dhcp6.OptionClientID, c.duid)
+
+	oro := dhcp6opts.OptionRequestOption{dhcp6.OptionDNSServers, dhcp6.OptionDomainList}
+	if err := opts.Add(dhcp6.OptionORO, oro); err != nil {
+		return nil, err
+	}
+
+	return opts, nil
+}
+
+// solicitOptions builds the Options map sent in a Solicit: c's base
+// options, plus an empty IA_NA (and, if c.PrefixLength is set, an IA_PD
+// carrying an IA_Prefix hint of that length) asking the server to
+// assign a new binding.
+func (c *Client) solicitOptions() (dhcp6.Options, error) {
+	opts, err := c.baseOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	iana, err := dhcp6opts.NewIANA(c.ensureIAID(), 0, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := opts.Add(dhcp6.OptionIANA, iana); err != nil {
+		return nil, err
+	}
+
+	if c.PrefixLength > 0 {
+		hint, err := dhcp6opts.NewIAPrefix(net.IPv6unspecified, c.PrefixLength, 0, 0, nil)
+		if err != nil {
+			return nil, err
+		}
+		pdOpts := make(dhcp6.Options)
+		if err := pdOpts.Add(dhcp6.OptionIAPrefix, hint); err != nil {
+			return nil, err
+		}
+
+		iapd, err := dhcp6opts.NewIAPD(c.ensureIAID(), 0, 0, pdOpts)
+		if err != nil {
+			return nil, err
+		}
+		if err := opts.Add(dhcp6.OptionIAPD, iapd); err != nil {
+			return nil, err
+		}
+	}
+
+	return opts, nil
+}
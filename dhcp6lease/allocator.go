@@ -0,0 +1,154 @@
+package dhcp6lease
+
+import (
+	"crypto/rand"
+	"net"
+	"sync"
+	"time"
+)
+
+// An Allocator assigns IPv6 addresses from a subnet to clients,
+// honoring MAC reservations and skipping addresses already leased.
+type Allocator struct {
+	subnet       *net.IPNet
+	store        Store
+	reservations map[string]net.IP
+
+	mu       sync.Mutex
+	declined map[string]bool
+}
+
+// NewAllocator creates an Allocator which assigns addresses from
+// subnet, recording them in store, and preferring the address
+// reserved for a client's hardware address in reservations, if any.
+func NewAllocator(subnet *net.IPNet, store Store, reservations map[string]net.IP) *Allocator {
+	return &Allocator{
+		subnet:       subnet,
+		store:        store,
+		reservations: reservations,
+		declined:     make(map[string]bool),
+	}
+}
+
+// OnLink reports whether ip falls within a.subnet, for use answering a
+// Confirm, as required by RFC 3315, Section 18.2.2.
+func (a *Allocator) OnLink(ip net.IP) bool {
+	return a.subnet.Contains(ip)
+}
+
+// Decline marks ip as unusable, so it is never handed out by a later
+// Allocate call, to this client or any other, as required when a
+// client reports it via Decline (RFC 3315, Section 18.1.7).
+func (a *Allocator) Decline(ip net.IP) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.declined[ip.String()] = true
+}
+
+// Allocate returns the Lease held by the client identified by duid and
+// iaid, renewing it with preferred and valid if one already exists and
+// still falls within a.subnet, or assigning it a new Lease otherwise:
+// its reservation in mac, if any, or the next free address in
+// a.subnet.
+func (a *Allocator) Allocate(duid []byte, iaid [4]byte, mac net.HardwareAddr, preferred, valid time.Duration, hostname string) (Lease, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	l, ok, err := a.store.Get(duid, iaid)
+	if err != nil {
+		return Lease{}, err
+	}
+
+	if !ok || !a.subnet.Contains(l.IP) {
+		ip, err := a.nextFreeLocked(mac)
+		if err != nil {
+			return Lease{}, err
+		}
+
+		l = Lease{
+			DUID: append([]byte(nil), duid...),
+			IAID: iaid,
+			IP:   ip,
+		}
+	}
+
+	l.Preferred = preferred
+	l.Valid = valid
+	l.Expiry = time.Now().Add(valid)
+	l.Hostname = hostname
+
+	if l.ReconfigureKey == ([16]byte{}) {
+		if _, err := rand.Read(l.ReconfigureKey[:]); err != nil {
+			return Lease{}, err
+		}
+	}
+
+	if err := a.store.Put(l); err != nil {
+		return Lease{}, err
+	}
+
+	return l, nil
+}
+
+// nextFreeLocked returns mac's reservation, if it has one and it is
+// neither leased nor declined, or the lowest unreserved, unleased address in
+// a.subnet otherwise.  It scans from a.subnet's base address every
+// call, rather than from a cursor, so that an address freed by Delete
+// is handed out again instead of the pool appearing exhausted.
+func (a *Allocator) nextFreeLocked(mac net.HardwareAddr) (net.IP, error) {
+	if mac != nil {
+		if ip, ok := a.reservations[mac.String()]; ok {
+			inUse, err := a.inUseLocked(ip)
+			if err != nil {
+				return nil, err
+			}
+			if !inUse && !a.declined[ip.String()] {
+				return ip, nil
+			}
+		}
+	}
+
+	for ip := nextIP(a.subnet.IP.Mask(a.subnet.Mask)); a.subnet.Contains(ip); ip = nextIP(ip) {
+		if a.reservedLocked(ip) || a.declined[ip.String()] {
+			continue
+		}
+
+		inUse, err := a.inUseLocked(ip)
+		if err != nil {
+			return nil, err
+		}
+		if inUse {
+			continue
+		}
+
+		return ip, nil
+	}
+
+	return nil, ErrPoolExhausted
+}
+
+// reservedLocked reports whether ip is reserved for some hardware
+// address.
+func (a *Allocator) reservedLocked(ip net.IP) bool {
+	for _, reserved := range a.reservations {
+		if reserved.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// inUseLocked reports whether ip is already held by a Lease in
+// a.store.
+func (a *Allocator) inUseLocked(ip net.IP) (bool, error) {
+	inUse := false
+	err := a.store.Range(func(l Lease) bool {
+		if l.IP.Equal(ip) {
+			inUse = true
+			return false
+		}
+		return true
+	})
+
+	return inUse, err
+}
@@ -0,0 +1,6 @@
+// Package dhcp6lease implements an IPv6 lease subsystem for use by a
+// dhcp6server.Plugin: a Store interface for recording Leases with
+// pluggable persistence, and an Allocator which assigns addresses from
+// a subnet to clients, honoring per-MAC reservations and skipping
+// addresses already leased.
+package dhcp6lease
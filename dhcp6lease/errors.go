@@ -0,0 +1,9 @@
+package dhcp6lease
+
+import "errors"
+
+var (
+	// ErrPoolExhausted is returned by an Allocator when no free address
+	// remains in its configured subnet.
+	ErrPoolExhausted = errors.New("dhcp6lease: address pool exhausted")
+)
@@ -0,0 +1,201 @@
+package dhcp6lease
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileStore is a Store which wraps a MemoryStore and persists its
+// Leases to a JSON file on every change, atomically rewriting it with
+// a temp file and rename, and reloading it on startup.
+type FileStore struct {
+	*MemoryStore
+	path   string
+	subnet *net.IPNet
+}
+
+// NewFileStore creates a FileStore backed by a MemoryStore whose
+// background goroutine reclaims expired Leases every interval,
+// persisting the result to path.  On startup, any Lease loaded from
+// path that has already expired, or whose IP no longer falls within
+// subnet, is discarded.
+func NewFileStore(path string, subnet *net.IPNet, interval time.Duration) (*FileStore, error) {
+	s := &FileStore{
+		path:   path,
+		subnet: subnet,
+	}
+	s.MemoryStore = newMemoryStore(interval, func() {
+		if err := s.save(); err != nil {
+			log.Println("dhcp6lease: persisting reclaimed leases:", err)
+		}
+	})
+
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Put implements Store.
+func (s *FileStore) Put(l Lease) error {
+	if err := s.MemoryStore.Put(l); err != nil {
+		return err
+	}
+	return s.save()
+}
+
+// Delete implements Store.
+func (s *FileStore) Delete(duid []byte, iaid [4]byte) error {
+	if err := s.MemoryStore.Delete(duid, iaid); err != nil {
+		return err
+	}
+	return s.save()
+}
+
+// fileLeaseEntry is the on-disk representation of a single Lease in a
+// FileStore's file.
+type fileLeaseEntry struct {
+	DUID      string    `json:"duid"`
+	IAID      string    `json:"iaid"`
+	IP        string    `json:"ip"`
+	Preferred string    `json:"preferred"`
+	Valid     string    `json:"valid"`
+	Expiry    time.Time `json:"expiry"`
+	Hostname  string    `json:"hostname,omitempty"`
+	Key       string    `json:"reconfigure_key"`
+}
+
+// load populates s's Leases from its file, if it exists.
+func (s *FileStore) load() error {
+	data, err := ioutil.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	var entries []fileLeaseEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, e := range entries {
+		l, ok, err := decodeLeaseEntry(e)
+		if err != nil {
+			return err
+		}
+		if !ok || !s.subnet.Contains(l.IP) || !l.Expiry.After(now) {
+			continue
+		}
+
+		if err := s.MemoryStore.Put(l); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// save atomically rewrites s's file with its current Leases, via a
+// temp file in the same directory followed by a rename.
+func (s *FileStore) save() error {
+	var entries []fileLeaseEntry
+	if err := s.MemoryStore.Range(func(l Lease) bool {
+		entries = append(entries, encodeLeaseEntry(l))
+		return true
+	}); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.path)
+}
+
+// encodeLeaseEntry converts a Lease to its on-disk representation.
+func encodeLeaseEntry(l Lease) fileLeaseEntry {
+	return fileLeaseEntry{
+		DUID:      hex.EncodeToString(l.DUID),
+		IAID:      hex.EncodeToString(l.IAID[:]),
+		IP:        l.IP.String(),
+		Preferred: l.Preferred.String(),
+		Valid:     l.Valid.String(),
+		Expiry:    l.Expiry,
+		Hostname:  l.Hostname,
+		Key:       hex.EncodeToString(l.ReconfigureKey[:]),
+	}
+}
+
+// decodeLeaseEntry parses e into a Lease.  It returns ok false, with a
+// nil error, for an entry that is well-formed JSON but does not decode
+// to a usable Lease (a malformed IP or IAID), so that load can skip it
+// without failing outright.
+func decodeLeaseEntry(e fileLeaseEntry) (Lease, bool, error) {
+	duid, err := hex.DecodeString(e.DUID)
+	if err != nil {
+		return Lease{}, false, nil
+	}
+
+	iaidRaw, err := hex.DecodeString(e.IAID)
+	if err != nil || len(iaidRaw) != 4 {
+		return Lease{}, false, nil
+	}
+	var iaid [4]byte
+	copy(iaid[:], iaidRaw)
+
+	ip := net.ParseIP(e.IP)
+	if ip == nil {
+		return Lease{}, false, nil
+	}
+
+	preferred, err := time.ParseDuration(e.Preferred)
+	if err != nil {
+		return Lease{}, false, nil
+	}
+	valid, err := time.ParseDuration(e.Valid)
+	if err != nil {
+		return Lease{}, false, nil
+	}
+
+	var reconfigureKey [16]byte
+	if raw, err := hex.DecodeString(e.Key); err == nil && len(raw) == 16 {
+		copy(reconfigureKey[:], raw)
+	}
+
+	return Lease{
+		DUID:           duid,
+		IAID:           iaid,
+		IP:             ip,
+		Preferred:      preferred,
+		Valid:          valid,
+		Expiry:         e.Expiry,
+		Hostname:       e.Hostname,
+		ReconfigureKey: reconfigureKey,
+	}, true, nil
+}
@@ -0,0 +1,134 @@
+package dhcp6lease
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestEncodeDecodeLeaseEntryRoundTrip(t *testing.T) {
+	want := Lease{
+		DUID:           []byte{0x00, 0x01, 0x02, 0x03},
+		IAID:           [4]byte{1, 2, 3, 4},
+		IP:             net.ParseIP("2001:db8::1"),
+		Preferred:      60 * time.Second,
+		Valid:          90 * time.Second,
+		Expiry:         time.Unix(1700000000, 0).UTC(),
+		Hostname:       "client-a",
+		ReconfigureKey: [16]byte{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+	}
+
+	entry := encodeLeaseEntry(want)
+	got, ok, err := decodeLeaseEntry(entry)
+	if err != nil {
+		t.Fatalf("decodeLeaseEntry: %v", err)
+	}
+	if !ok {
+		t.Fatal("decodeLeaseEntry: ok = false, want true")
+	}
+
+	if string(got.DUID) != string(want.DUID) {
+		t.Errorf("DUID: got %v, want %v", got.DUID, want.DUID)
+	}
+	if got.IAID != want.IAID {
+		t.Errorf("IAID: got %v, want %v", got.IAID, want.IAID)
+	}
+	if !got.IP.Equal(want.IP) {
+		t.Errorf("IP: got %v, want %v", got.IP, want.IP)
+	}
+	if got.Preferred != want.Preferred {
+		t.Errorf("Preferred: got %v, want %v", got.Preferred, want.Preferred)
+	}
+	if got.Valid != want.Valid {
+		t.Errorf("Valid: got %v, want %v", got.Valid, want.Valid)
+	}
+	if !got.Expiry.Equal(want.Expiry) {
+		t.Errorf("Expiry: got %v, want %v", got.Expiry, want.Expiry)
+	}
+	if got.Hostname != want.Hostname {
+		t.Errorf("Hostname: got %q, want %q", got.Hostname, want.Hostname)
+	}
+	if got.ReconfigureKey != want.ReconfigureKey {
+		t.Errorf("ReconfigureKey: got %v, want %v", got.ReconfigureKey, want.ReconfigureKey)
+	}
+}
+
+func TestNewFileStoreDropsExpiredLeasesOnLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dhcp6lease")
+	if err != nil {
+		t.Fatalf("TempDir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	_, subnet, err := net.ParseCIDR("2001:db8::/64")
+	if err != nil {
+		t.Fatalf("ParseCIDR: %v", err)
+	}
+
+	expired := encodeLeaseEntry(Lease{
+		DUID:      []byte{0x00, 0x01},
+		IAID:      [4]byte{1, 0, 0, 0},
+		IP:        net.ParseIP("2001:db8::1"),
+		Preferred: time.Second,
+		Valid:     time.Second,
+		Expiry:    time.Now().Add(-time.Hour),
+	})
+	current := encodeLeaseEntry(Lease{
+		DUID:      []byte{0x00, 0x02},
+		IAID:      [4]byte{2, 0, 0, 0},
+		IP:        net.ParseIP("2001:db8::2"),
+		Preferred: time.Second,
+		Valid:     time.Hour,
+		Expiry:    time.Now().Add(time.Hour),
+	})
+
+	path := filepath.Join(dir, "leases.json")
+	data, err := json.Marshal([]fileLeaseEntry{expired, current})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if err := ioutil.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	s, err := NewFileStore(path, subnet, time.Hour)
+	if err != nil {
+		t.Fatalf("NewFileStore: %v", err)
+	}
+	defer s.Close()
+
+	if _, ok, err := s.Get([]byte{0x00, 0x01}, [4]byte{1, 0, 0, 0}); err != nil {
+		t.Fatalf("Get(expired): %v", err)
+	} else if ok {
+		t.Error("Get(expired) found a lease that should have been dropped on load")
+	}
+
+	if _, ok, err := s.Get([]byte{0x00, 0x02}, [4]byte{2, 0, 0, 0}); err != nil {
+		t.Fatalf("Get(current): %v", err)
+	} else if !ok {
+		t.Error("Get(current) found no lease, want the still-valid one to survive load")
+	}
+}
+
+func TestDecodeLeaseEntryMalformedIPSkipped(t *testing.T) {
+	entry := encodeLeaseEntry(Lease{
+		DUID:      []byte{0x00, 0x01},
+		IAID:      [4]byte{1, 2, 3, 4},
+		IP:        net.ParseIP("2001:db8::1"),
+		Preferred: time.Second,
+		Valid:     time.Second,
+	})
+	entry.IP = "not-an-ip"
+
+	_, ok, err := decodeLeaseEntry(entry)
+	if err != nil {
+		t.Fatalf("decodeLeaseEntry: %v", err)
+	}
+	if ok {
+		t.Fatal("decodeLeaseEntry: ok = true, want false for a malformed IP")
+	}
+}
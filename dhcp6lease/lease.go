@@ -0,0 +1,61 @@
+package dhcp6lease
+
+import (
+	"encoding/hex"
+	"net"
+	"time"
+)
+
+// A Lease is an IPv6 address assignment held by a client, identified
+// by its DUID and IAID.
+type Lease struct {
+	DUID      []byte
+	IAID      [4]byte
+	IP        net.IP
+	Preferred time.Duration
+	Valid     time.Duration
+	Expiry    time.Time
+	Hostname  string
+
+	// ReconfigureKey is the Reconfigure key generated for this client
+	// when its Lease was first committed, used to authenticate a
+	// later Reconfigure (RFC 3315, Section 21.5).
+	ReconfigureKey [16]byte
+}
+
+// A Store records Leases, keyed by the DUID and IAID of the client
+// holding them.
+type Store interface {
+	// Get returns the Lease held by the client identified by duid and
+	// iaid, and reports whether one was found.
+	Get(duid []byte, iaid [4]byte) (Lease, bool, error)
+
+	// Put inserts or replaces l, keyed by its DUID and IAID.
+	Put(l Lease) error
+
+	// Delete removes the Lease held by the client identified by duid
+	// and iaid, if any.
+	Delete(duid []byte, iaid [4]byte) error
+
+	// Range calls fn for every Lease in the Store, in no particular
+	// order, until fn returns false.
+	Range(fn func(Lease) bool) error
+}
+
+// key uniquely identifies a client's IA within a Store.
+func key(duid []byte, iaid [4]byte) string {
+	return hex.EncodeToString(duid) + "/" + hex.EncodeToString(iaid[:])
+}
+
+// nextIP returns the IPv6 address immediately following ip.
+func nextIP(ip net.IP) net.IP {
+	next := make(net.IP, len(ip.To16()))
+	copy(next, ip.To16())
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			break
+		}
+	}
+	return next
+}
@@ -0,0 +1,159 @@
+package dhcp6lease
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+// MemoryStore is a Store which keeps Leases in a map, reclaiming
+// expired entries in the background using a min-heap ordered by
+// expiry.
+type MemoryStore struct {
+	mu     sync.Mutex
+	leases map[string]Lease
+	expiry expiryHeap
+
+	onReclaim func()
+	done      chan struct{}
+}
+
+// NewMemoryStore creates a MemoryStore whose background goroutine
+// reclaims expired Leases every interval.
+func NewMemoryStore(interval time.Duration) *MemoryStore {
+	return newMemoryStore(interval, nil)
+}
+
+// newMemoryStore creates a MemoryStore as NewMemoryStore does, calling
+// onReclaim, if non-nil, after its background goroutine reclaims one
+// or more expired Leases -- used by FileStore to persist a reclaim.
+func newMemoryStore(interval time.Duration, onReclaim func()) *MemoryStore {
+	s := &MemoryStore{
+		leases:    make(map[string]Lease),
+		onReclaim: onReclaim,
+		done:      make(chan struct{}),
+	}
+
+	go s.reclaimLoop(interval)
+
+	return s
+}
+
+// Close stops s's background reclaim goroutine.
+func (s *MemoryStore) Close() error {
+	close(s.done)
+	return nil
+}
+
+// Get implements Store.
+func (s *MemoryStore) Get(duid []byte, iaid [4]byte) (Lease, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	l, ok := s.leases[key(duid, iaid)]
+	return l, ok, nil
+}
+
+// Put implements Store.
+func (s *MemoryStore) Put(l Lease) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := key(l.DUID, l.IAID)
+	s.leases[k] = l
+	heap.Push(&s.expiry, expiryEntry{key: k, expiry: l.Expiry})
+
+	return nil
+}
+
+// Delete implements Store.
+func (s *MemoryStore) Delete(duid []byte, iaid [4]byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.leases, key(duid, iaid))
+	return nil
+}
+
+// Range implements Store.
+func (s *MemoryStore) Range(fn func(Lease) bool) error {
+	s.mu.Lock()
+	leases := make([]Lease, 0, len(s.leases))
+	for _, l := range s.leases {
+		leases = append(leases, l)
+	}
+	s.mu.Unlock()
+
+	for _, l := range leases {
+		if !fn(l) {
+			break
+		}
+	}
+
+	return nil
+}
+
+// reclaimLoop calls reclaim every interval, until Close is called.
+func (s *MemoryStore) reclaimLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.reclaim()
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// reclaim pops every entry due to expire off s.expiry, deleting its
+// Lease unless it has since been renewed to a later expiry -- in which
+// case that renewal pushed its own, later entry, and this one is
+// stale.  If it deletes at least one Lease, it calls s.onReclaim, if
+// set, once all of them have been removed.
+func (s *MemoryStore) reclaim() {
+	now := time.Now()
+
+	s.mu.Lock()
+	var reclaimed bool
+	for s.expiry.Len() > 0 && !s.expiry[0].expiry.After(now) {
+		next := heap.Pop(&s.expiry).(expiryEntry)
+
+		if l, ok := s.leases[next.key]; ok && !l.Expiry.After(next.expiry) {
+			delete(s.leases, next.key)
+			reclaimed = true
+		}
+	}
+	s.mu.Unlock()
+
+	if reclaimed && s.onReclaim != nil {
+		s.onReclaim()
+	}
+}
+
+// expiryEntry is a MemoryStore lease key ordered by its Lease's
+// Expiry, for use in an expiryHeap.
+type expiryEntry struct {
+	key    string
+	expiry time.Time
+}
+
+// expiryHeap is a container/heap.Interface of expiryEntry, ordered by
+// ascending expiry, letting MemoryStore find the next Lease due for
+// reclamation without scanning every entry.
+type expiryHeap []expiryEntry
+
+func (h expiryHeap) Len() int            { return len(h) }
+func (h expiryHeap) Less(i, j int) bool  { return h[i].expiry.Before(h[j].expiry) }
+func (h expiryHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *expiryHeap) Push(x interface{}) { *h = append(*h, x.(expiryEntry)) }
+
+func (h *expiryHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
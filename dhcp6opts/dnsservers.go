@@ -0,0 +1,40 @@
+package dhcp6opts
+
+import (
+	"net"
+
+	"github.com/oiooj/dhcp6d"
+)
+
+// DNSServers is a list of IPv6 addresses of DNS recursive name servers to
+// which a client may send DNS queries, as defined in RFC 3646, Section 3
+// (option code 23).
+type DNSServers []net.IP
+
+// MarshalBinary allocates a byte slice containing the data from a
+// DNSServers list.
+func (d DNSServers) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, 16*len(d))
+	for _, ip := range d {
+		b = append(b, ip.To16()...)
+	}
+
+	return b, nil
+}
+
+// UnmarshalBinary unmarshals a raw byte slice into a DNSServers list.
+func (d *DNSServers) UnmarshalBinary(b []byte) error {
+	if len(b)%16 != 0 {
+		return dhcp6.ErrInvalidOptionVal
+	}
+
+	servers := make(DNSServers, 0, len(b)/16)
+	for i := 0; i < len(b); i += 16 {
+		ip := make(net.IP, 16)
+		copy(ip, b[i:i+16])
+		servers = append(servers, ip)
+	}
+
+	*d = servers
+	return nil
+}
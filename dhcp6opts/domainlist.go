@@ -0,0 +1,58 @@
+package dhcp6opts
+
+import (
+	"strings"
+
+	"github.com/oiooj/dhcp6d"
+)
+
+// DomainList is a list of domain names to be used as a client's DNS
+// search list, as defined in RFC 3646, Section 4 (option code 24).  Each
+// domain name is encoded using the DNS wire format described in RFC
+// 1035, Section 3.1, without message compression.
+type DomainList []string
+
+// MarshalBinary allocates a byte slice containing the data from a
+// DomainList.
+func (d DomainList) MarshalBinary() ([]byte, error) {
+	var b []byte
+	for _, name := range d {
+		for _, label := range strings.Split(strings.Trim(name, "."), ".") {
+			if len(label) > 63 {
+				return nil, dhcp6.ErrInvalidOptionVal
+			}
+			b = append(b, byte(len(label)))
+			b = append(b, label...)
+		}
+		b = append(b, 0)
+	}
+
+	return b, nil
+}
+
+// UnmarshalBinary unmarshals a raw byte slice into a DomainList.
+func (d *DomainList) UnmarshalBinary(b []byte) error {
+	var names DomainList
+	var labels []string
+
+	for len(b) > 0 {
+		n := int(b[0])
+		b = b[1:]
+
+		if n == 0 {
+			names = append(names, strings.Join(labels, "."))
+			labels = nil
+			continue
+		}
+
+		if n > len(b) {
+			return dhcp6.ErrInvalidOptionVal
+		}
+
+		labels = append(labels, string(b[:n]))
+		b = b[n:]
+	}
+
+	*d = names
+	return nil
+}
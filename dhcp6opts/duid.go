@@ -0,0 +1,123 @@
+// Package dhcp6opts implements the DHCPv6 options defined in RFC 3315
+// and subsequent RFCs, building on the wire types in package dhcp6.
+package dhcp6opts
+
+import (
+	"encoding/binary"
+	"net"
+	"time"
+
+	"github.com/oiooj/dhcp6d"
+)
+
+// DUID type constants, as defined in RFC 3315, Section 9.
+const (
+	duidLLTType uint16 = 1
+	duidENType  uint16 = 2
+	duidLLType  uint16 = 3
+)
+
+// A DUID is a DHCP Unique Identifier, as defined in RFC 3315, Section 9.
+// A DUID is used to uniquely identify a client or server for the
+// duration of a client's attachment to the network.
+type DUID interface {
+	dhcp6.Marshaler
+	dhcp6.Unmarshaler
+}
+
+// A DUIDLLT is a DUID which contains a hardware type, a timestamp, and a
+// hardware (MAC) address, as defined in RFC 3315, Section 9.2.
+type DUIDLLT struct {
+	HardwareType uint16
+	Time         uint32
+	HardwareAddr net.HardwareAddr
+}
+
+// NewDUIDLLT creates a new DUIDLLT from an IANA hardware type, a time
+// value, and a hardware address.
+func NewDUIDLLT(hardwareType uint16, t time.Time, addr net.HardwareAddr) *DUIDLLT {
+	return &DUIDLLT{
+		HardwareType: hardwareType,
+		// RFC 3315, Section 9.2: time is the number of seconds since
+		// midnight (UTC), January 1, 2000.
+		Time:         uint32(t.Unix() - duidEpoch),
+		HardwareAddr: addr,
+	}
+}
+
+// duidEpoch is the Unix timestamp of midnight (UTC), January 1, 2000.
+const duidEpoch = 946684800
+
+// MarshalBinary allocates a byte slice containing the data from a DUIDLLT.
+func (d *DUIDLLT) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 8+len(d.HardwareAddr))
+	binary.BigEndian.PutUint16(b[0:2], duidLLTType)
+	binary.BigEndian.PutUint16(b[2:4], d.HardwareType)
+	binary.BigEndian.PutUint32(b[4:8], d.Time)
+	copy(b[8:], d.HardwareAddr)
+
+	return b, nil
+}
+
+// UnmarshalBinary unmarshals a raw byte slice into a DUIDLLT.
+func (d *DUIDLLT) UnmarshalBinary(b []byte) error {
+	if len(b) < 8 {
+		return dhcp6.ErrInvalidOptionVal
+	}
+	if binary.BigEndian.Uint16(b[0:2]) != duidLLTType {
+		return dhcp6.ErrInvalidOptionVal
+	}
+
+	d.HardwareType = binary.BigEndian.Uint16(b[2:4])
+	d.Time = binary.BigEndian.Uint32(b[4:8])
+
+	addr := make(net.HardwareAddr, len(b[8:]))
+	copy(addr, b[8:])
+	d.HardwareAddr = addr
+
+	return nil
+}
+
+// A DUIDLL is a DUID which contains a hardware type and a hardware (MAC)
+// address, as defined in RFC 3315, Section 9.4.
+type DUIDLL struct {
+	HardwareType uint16
+	HardwareAddr net.HardwareAddr
+}
+
+// NewDUIDLL creates a new DUIDLL from an IANA hardware type and a
+// hardware address.
+func NewDUIDLL(hardwareType uint16, addr net.HardwareAddr) *DUIDLL {
+	return &DUIDLL{
+		HardwareType: hardwareType,
+		HardwareAddr: addr,
+	}
+}
+
+// MarshalBinary allocates a byte slice containing the data from a DUIDLL.
+func (d *DUIDLL) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 4+len(d.HardwareAddr))
+	binary.BigEndian.PutUint16(b[0:2], duidLLType)
+	binary.BigEndian.PutUint16(b[2:4], d.HardwareType)
+	copy(b[4:], d.HardwareAddr)
+
+	return b, nil
+}
+
+// UnmarshalBinary unmarshals a raw byte slice into a DUIDLL.
+func (d *DUIDLL) UnmarshalBinary(b []byte) error {
+	if len(b) < 4 {
+		return dhcp6.ErrInvalidOptionVal
+	}
+	if binary.BigEndian.Uint16(b[0:2]) != duidLLType {
+		return dhcp6.ErrInvalidOptionVal
+	}
+
+	d.HardwareType = binary.BigEndian.Uint16(b[2:4])
+
+	addr := make(net.HardwareAddr, len(b[4:]))
+	copy(addr, b[4:])
+	d.HardwareAddr = addr
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package dhcp6opts
+
+import (
+	"encoding/binary"
+	"net"
+	"time"
+
+	"github.com/oiooj/dhcp6d"
+)
+
+// An IAAddr is an IA Address option, as defined in RFC 3315, Section
+// 22.6.  It carries a single IPv6 address assigned to an IANA or IATA.
+type IAAddr struct {
+	IP                net.IP
+	PreferredLifetime time.Duration
+	ValidLifetime     time.Duration
+	Options           dhcp6.Options
+}
+
+// NewIAAddr creates a new IAAddr from an IPv6 address, preferred and
+// valid lifetimes, and an Options map.
+func NewIAAddr(ip net.IP, preferred, valid time.Duration, options dhcp6.Options) (*IAAddr, error) {
+	if ip.To4() != nil || ip.To16() == nil {
+		return nil, dhcp6.ErrInvalidOptionVal
+	}
+
+	if options == nil {
+		options = make(dhcp6.Options)
+	}
+
+	return &IAAddr{
+		IP:                ip,
+		PreferredLifetime: preferred,
+		ValidLifetime:     valid,
+		Options:           options,
+	}, nil
+}
+
+// MarshalBinary allocates a byte slice containing the data from an IAAddr.
+func (a *IAAddr) MarshalBinary() ([]byte, error) {
+	opts, err := a.Options.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, 24+len(opts))
+	copy(b[0:16], a.IP.To16())
+	binary.BigEndian.PutUint32(b[16:20], uint32(a.PreferredLifetime/time.Second))
+	binary.BigEndian.PutUint32(b[20:24], uint32(a.ValidLifetime/time.Second))
+	copy(b[24:], opts)
+
+	return b, nil
+}
+
+// UnmarshalBinary unmarshals a raw byte slice into an IAAddr.
+func (a *IAAddr) UnmarshalBinary(b []byte) error {
+	if len(b) < 24 {
+		return dhcp6.ErrInvalidOptionVal
+	}
+
+	ip := make(net.IP, 16)
+	copy(ip, b[0:16])
+	a.IP = ip
+
+	a.PreferredLifetime = time.Duration(binary.BigEndian.Uint32(b[16:20])) * time.Second
+	a.ValidLifetime = time.Duration(binary.BigEndian.Uint32(b[20:24])) * time.Second
+
+	options, err := dhcp6.ParseOptions(b[24:])
+	if err != nil {
+		return err
+	}
+	a.Options = options
+
+	return nil
+}
+
+// GetIAAddr fetches and parses every IAAddr option found in an Options
+// map.  If no options are found, dhcp6.ErrOptionNotPresent is returned.
+func GetIAAddr(options dhcp6.Options) ([]*IAAddr, error) {
+	vv, ok := options.Get(dhcp6.OptionIAAddr)
+	if !ok {
+		return nil, dhcp6.ErrOptionNotPresent
+	}
+
+	addrs := make([]*IAAddr, 0, len(vv))
+	for _, v := range vv {
+		a := new(IAAddr)
+		if err := a.UnmarshalBinary(v); err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, a)
+	}
+
+	return addrs, nil
+}
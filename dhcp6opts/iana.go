@@ -0,0 +1,88 @@
+package dhcp6opts
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/oiooj/dhcp6d"
+)
+
+// An IANA is an Identity Association for Non-temporary Addresses, as
+// defined in RFC 3315, Section 22.4.  It is used to carry an identity
+// association, T1/T2 times, and any IAAddr options assigned to a client.
+type IANA struct {
+	IAID    [4]byte
+	T1      time.Duration
+	T2      time.Duration
+	Options dhcp6.Options
+}
+
+// NewIANA creates a new IANA from an IAID, T1/T2 values, and an Options
+// map.
+func NewIANA(iaid [4]byte, t1, t2 time.Duration, options dhcp6.Options) (*IANA, error) {
+	if options == nil {
+		options = make(dhcp6.Options)
+	}
+
+	return &IANA{
+		IAID:    iaid,
+		T1:      t1,
+		T2:      t2,
+		Options: options,
+	}, nil
+}
+
+// MarshalBinary allocates a byte slice containing the data from an IANA.
+func (i *IANA) MarshalBinary() ([]byte, error) {
+	opts, err := i.Options.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, 12+len(opts))
+	copy(b[0:4], i.IAID[:])
+	binary.BigEndian.PutUint32(b[4:8], uint32(i.T1/time.Second))
+	binary.BigEndian.PutUint32(b[8:12], uint32(i.T2/time.Second))
+	copy(b[12:], opts)
+
+	return b, nil
+}
+
+// UnmarshalBinary unmarshals a raw byte slice into an IANA.
+func (i *IANA) UnmarshalBinary(b []byte) error {
+	if len(b) < 12 {
+		return dhcp6.ErrInvalidOptionVal
+	}
+
+	copy(i.IAID[:], b[0:4])
+	i.T1 = time.Duration(binary.BigEndian.Uint32(b[4:8])) * time.Second
+	i.T2 = time.Duration(binary.BigEndian.Uint32(b[8:12])) * time.Second
+
+	options, err := dhcp6.ParseOptions(b[12:])
+	if err != nil {
+		return err
+	}
+	i.Options = options
+
+	return nil
+}
+
+// GetIANA fetches and parses every IANA option found in an Options map.
+// If no options are found, dhcp6.ErrOptionNotPresent is returned.
+func GetIANA(options dhcp6.Options) ([]*IANA, error) {
+	vv, ok := options.Get(dhcp6.OptionIANA)
+	if !ok {
+		return nil, dhcp6.ErrOptionNotPresent
+	}
+
+	ianas := make([]*IANA, 0, len(vv))
+	for _, v := range vv {
+		ia := new(IANA)
+		if err := ia.UnmarshalBinary(v); err != nil {
+			return nil, err
+		}
+		ianas = append(ianas, ia)
+	}
+
+	return ianas, nil
+}
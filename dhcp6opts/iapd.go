@@ -0,0 +1,88 @@
+package dhcp6opts
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/oiooj/dhcp6d"
+)
+
+// An IAPD is an Identity Association for Prefix Delegation, as defined
+// in RFC 3633, Section 9.  It is used to carry an identity association,
+// T1/T2 times, and any IAPrefix options delegated to a client.
+type IAPD struct {
+	IAID    [4]byte
+	T1      time.Duration
+	T2      time.Duration
+	Options dhcp6.Options
+}
+
+// NewIAPD creates a new IAPD from an IAID, T1/T2 values, and an Options
+// map.
+func NewIAPD(iaid [4]byte, t1, t2 time.Duration, options dhcp6.Options) (*IAPD, error) {
+	if options == nil {
+		options = make(dhcp6.Options)
+	}
+
+	return &IAPD{
+		IAID:    iaid,
+		T1:      t1,
+		T2:      t2,
+		Options: options,
+	}, nil
+}
+
+// MarshalBinary allocates a byte slice containing the data from an IAPD.
+func (i *IAPD) MarshalBinary() ([]byte, error) {
+	opts, err := i.Options.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, 12+len(opts))
+	copy(b[0:4], i.IAID[:])
+	binary.BigEndian.PutUint32(b[4:8], uint32(i.T1/time.Second))
+	binary.BigEndian.PutUint32(b[8:12], uint32(i.T2/time.Second))
+	copy(b[12:], opts)
+
+	return b, nil
+}
+
+// UnmarshalBinary unmarshals a raw byte slice into an IAPD.
+func (i *IAPD) UnmarshalBinary(b []byte) error {
+	if len(b) < 12 {
+		return dhcp6.ErrInvalidOptionVal
+	}
+
+	copy(i.IAID[:], b[0:4])
+	i.T1 = time.Duration(binary.BigEndian.Uint32(b[4:8])) * time.Second
+	i.T2 = time.Duration(binary.BigEndian.Uint32(b[8:12])) * time.Second
+
+	options, err := dhcp6.ParseOptions(b[12:])
+	if err != nil {
+		return err
+	}
+	i.Options = options
+
+	return nil
+}
+
+// GetIAPD fetches and parses every IAPD option found in an Options map.
+// If no options are found, dhcp6.ErrOptionNotPresent is returned.
+func GetIAPD(options dhcp6.Options) ([]*IAPD, error) {
+	vv, ok := options.Get(dhcp6.OptionIAPD)
+	if !ok {
+		return nil, dhcp6.ErrOptionNotPresent
+	}
+
+	iapds := make([]*IAPD, 0, len(vv))
+	for _, v := range vv {
+		ipd := new(IAPD)
+		if err := ipd.UnmarshalBinary(v); err != nil {
+			return nil, err
+		}
+		iapds = append(iapds, ipd)
+	}
+
+	return iapds, nil
+}
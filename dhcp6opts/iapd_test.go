@@ -0,0 +1,74 @@
+package dhcp6opts
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/oiooj/dhcp6d"
+)
+
+func TestIAPDMarshalUnmarshal(t *testing.T) {
+	prefix, err := NewIAPrefix(net.ParseIP("2001:db8:1::"), 64, 60*time.Second, 90*time.Second, nil)
+	if err != nil {
+		t.Fatalf("NewIAPrefix: %v", err)
+	}
+
+	opts := make(dhcp6.Options)
+	if err := opts.Add(dhcp6.OptionIAPrefix, prefix); err != nil {
+		t.Fatalf("Add(OptionIAPrefix): %v", err)
+	}
+
+	iaid := [4]byte{1, 2, 3, 4}
+	want, err := NewIAPD(iaid, 30*time.Second, 48*time.Second, opts)
+	if err != nil {
+		t.Fatalf("NewIAPD: %v", err)
+	}
+
+	b, err := want.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+
+	got := new(IAPD)
+	if err := got.UnmarshalBinary(b); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+
+	if got.IAID != want.IAID {
+		t.Errorf("IAID: got %v, want %v", got.IAID, want.IAID)
+	}
+	if got.T1 != want.T1 {
+		t.Errorf("T1: got %v, want %v", got.T1, want.T1)
+	}
+	if got.T2 != want.T2 {
+		t.Errorf("T2: got %v, want %v", got.T2, want.T2)
+	}
+
+	prefixes, err := GetIAPrefix(got.Options)
+	if err != nil {
+		t.Fatalf("GetIAPrefix: %v", err)
+	}
+	if len(prefixes) != 1 {
+		t.Fatalf("GetIAPrefix returned %d prefixes, want 1", len(prefixes))
+	}
+	if !prefixes[0].Prefix.Equal(prefix.Prefix) {
+		t.Errorf("Prefix: got %v, want %v", prefixes[0].Prefix, prefix.Prefix)
+	}
+	if prefixes[0].PrefixLength != prefix.PrefixLength {
+		t.Errorf("PrefixLength: got %d, want %d", prefixes[0].PrefixLength, prefix.PrefixLength)
+	}
+}
+
+func TestIAPDUnmarshalBinaryShort(t *testing.T) {
+	i := new(IAPD)
+	if err := i.UnmarshalBinary(make([]byte, 11)); err != dhcp6.ErrInvalidOptionVal {
+		t.Fatalf("UnmarshalBinary(11 bytes) = %v, want ErrInvalidOptionVal", err)
+	}
+}
+
+func TestNewIAPrefixInvalidPrefix(t *testing.T) {
+	if _, err := NewIAPrefix(net.ParseIP("192.0.2.1"), 64, 0, 0, nil); err != dhcp6.ErrInvalidOptionVal {
+		t.Fatalf("NewIAPrefix(IPv4) = %v, want ErrInvalidOptionVal", err)
+	}
+}
@@ -0,0 +1,104 @@
+package dhcp6opts
+
+import (
+	"encoding/binary"
+	"net"
+	"time"
+
+	"github.com/oiooj/dhcp6d"
+)
+
+// An IAPrefix is an IA Prefix option, as defined in RFC 3633, Section
+// 10.  It carries a single delegated IPv6 prefix assigned to an IAPD.
+type IAPrefix struct {
+	PreferredLifetime time.Duration
+	ValidLifetime     time.Duration
+	PrefixLength      uint8
+	Prefix            net.IP
+	Options           dhcp6.Options
+}
+
+// NewIAPrefix creates a new IAPrefix from an IPv6 prefix and length,
+// preferred and valid lifetimes, and an Options map.
+func NewIAPrefix(prefix net.IP, prefixLength uint8, preferred, valid time.Duration, options dhcp6.Options) (*IAPrefix, error) {
+	if prefix.To4() != nil || prefix.To16() == nil {
+		return nil, dhcp6.ErrInvalidOptionVal
+	}
+	if prefixLength > 128 {
+		return nil, dhcp6.ErrInvalidOptionVal
+	}
+
+	if options == nil {
+		options = make(dhcp6.Options)
+	}
+
+	return &IAPrefix{
+		PreferredLifetime: preferred,
+		ValidLifetime:     valid,
+		PrefixLength:      prefixLength,
+		Prefix:            prefix,
+		Options:           options,
+	}, nil
+}
+
+// MarshalBinary allocates a byte slice containing the data from an
+// IAPrefix.
+func (p *IAPrefix) MarshalBinary() ([]byte, error) {
+	opts, err := p.Options.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, 25+len(opts))
+	binary.BigEndian.PutUint32(b[0:4], uint32(p.PreferredLifetime/time.Second))
+	binary.BigEndian.PutUint32(b[4:8], uint32(p.ValidLifetime/time.Second))
+	b[8] = p.PrefixLength
+	copy(b[9:25], p.Prefix.To16())
+	copy(b[25:], opts)
+
+	return b, nil
+}
+
+// UnmarshalBinary unmarshals a raw byte slice into an IAPrefix.
+func (p *IAPrefix) UnmarshalBinary(b []byte) error {
+	if len(b) < 25 {
+		return dhcp6.ErrInvalidOptionVal
+	}
+
+	p.PreferredLifetime = time.Duration(binary.BigEndian.Uint32(b[0:4])) * time.Second
+	p.ValidLifetime = time.Duration(binary.BigEndian.Uint32(b[4:8])) * time.Second
+	p.PrefixLength = b[8]
+
+	prefix := make(net.IP, 16)
+	copy(prefix, b[9:25])
+	p.Prefix = prefix
+
+	options, err := dhcp6.ParseOptions(b[25:])
+	if err != nil {
+		return err
+	}
+	p.Options = options
+
+	return nil
+}
+
+// GetIAPrefix fetches and parses every IAPrefix option found in an
+// Options map.  If no options are found, dhcp6.ErrOptionNotPresent is
+// returned.
+func GetIAPrefix(options dhcp6.Options) ([]*IAPrefix, error) {
+	vv, ok := options.Get(dhcp6.OptionIAPrefix)
+	if !ok {
+		return nil, dhcp6.ErrOptionNotPresent
+	}
+
+	prefixes := make([]*IAPrefix, 0, len(vv))
+	for _, v := range vv {
+		ia := new(IAPrefix)
+		if err := ia.UnmarshalBinary(v); err != nil {
+			return nil, err
+		}
+		prefixes = append(prefixes, ia)
+	}
+
+	return prefixes, nil
+}
@@ -0,0 +1,54 @@
+package dhcp6opts
+
+import (
+	"encoding/binary"
+
+	"github.com/oiooj/dhcp6d"
+)
+
+// An OptionRequestOption is used by a client to request specific options
+// be sent to it by a server, as defined in RFC 3315, Section 22.7.
+type OptionRequestOption []dhcp6.OptionCode
+
+// MarshalBinary allocates a byte slice containing the data from an
+// OptionRequestOption.
+func (o OptionRequestOption) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 2*len(o))
+	for i, c := range o {
+		binary.BigEndian.PutUint16(b[i*2:i*2+2], uint16(c))
+	}
+
+	return b, nil
+}
+
+// UnmarshalBinary unmarshals a raw byte slice into an OptionRequestOption.
+func (o *OptionRequestOption) UnmarshalBinary(b []byte) error {
+	if len(b)%2 != 0 {
+		return dhcp6.ErrInvalidOptionVal
+	}
+
+	codes := make([]dhcp6.OptionCode, 0, len(b)/2)
+	for i := 0; i < len(b); i += 2 {
+		codes = append(codes, dhcp6.OptionCode(binary.BigEndian.Uint16(b[i:i+2])))
+	}
+
+	*o = codes
+	return nil
+}
+
+// GetOptionRequest fetches and parses an OptionRequestOption from an
+// Options map.  If the option is not found, dhcp6.ErrOptionNotPresent is
+// returned.
+func GetOptionRequest(options dhcp6.Options) (OptionRequestOption, error) {
+	v, err := options.GetOne(dhcp6.OptionORO)
+	if err != nil {
+		return nil, err
+	}
+
+	oro := new(OptionRequestOption)
+	if err := oro.UnmarshalBinary(v); err != nil {
+		return nil, err
+	}
+
+	return *oro, nil
+}
@@ -0,0 +1,26 @@
+package dhcp6opts
+
+import "github.com/oiooj/dhcp6d"
+
+// A Preference is an integer, 0-255, used to indicate to a client the
+// relative preference of a server among other servers it received
+// Advertise messages from, as defined in RFC 3315, Section 22.8.  A
+// Preference value of 255 instructs a client to immediately accept the
+// server's Advertise, without waiting for others.
+type Preference uint8
+
+// MarshalBinary allocates a byte slice containing the data from a
+// Preference.
+func (p Preference) MarshalBinary() ([]byte, error) {
+	return []byte{byte(p)}, nil
+}
+
+// UnmarshalBinary unmarshals a raw byte slice into a Preference.
+func (p *Preference) UnmarshalBinary(b []byte) error {
+	if len(b) != 1 {
+		return dhcp6.ErrInvalidOptionVal
+	}
+
+	*p = Preference(b[0])
+	return nil
+}
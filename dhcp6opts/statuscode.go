@@ -0,0 +1,69 @@
+package dhcp6opts
+
+import (
+	"encoding/binary"
+
+	"github.com/oiooj/dhcp6d"
+)
+
+// StatusCode values, as defined in RFC 3315, Section 24.4, and RFC 3633,
+// Section 10.1.
+const (
+	StatusSuccess       uint16 = 0
+	StatusUnspecFail    uint16 = 1
+	StatusNoAddrsAvail  uint16 = 2
+	StatusNoBinding     uint16 = 3
+	StatusNotOnLink     uint16 = 4
+	StatusUseMulticast  uint16 = 5
+	StatusNoPrefixAvail uint16 = 6
+)
+
+// A StatusCode conveys the success or failure of an operation, as
+// defined in RFC 3315, Section 22.13 (option code 13).
+type StatusCode struct {
+	Code    uint16
+	Message string
+}
+
+// NewStatusCode creates a new StatusCode from a code and message.
+func NewStatusCode(code uint16, message string) *StatusCode {
+	return &StatusCode{Code: code, Message: message}
+}
+
+// MarshalBinary allocates a byte slice containing the data from a
+// StatusCode.
+func (s *StatusCode) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 2+len(s.Message))
+	binary.BigEndian.PutUint16(b[0:2], s.Code)
+	copy(b[2:], s.Message)
+
+	return b, nil
+}
+
+// UnmarshalBinary unmarshals a raw byte slice into a StatusCode.
+func (s *StatusCode) UnmarshalBinary(b []byte) error {
+	if len(b) < 2 {
+		return dhcp6.ErrInvalidOptionVal
+	}
+
+	s.Code = binary.BigEndian.Uint16(b[0:2])
+	s.Message = string(b[2:])
+
+	return nil
+}
+
+// GetStatusCode fetches and parses a StatusCode from an Options map.  If
+// the option is not found, dhcp6.ErrOptionNotPresent is returned.
+func GetStatusCode(options dhcp6.Options) (*StatusCode, error) {
+	v, err := options.GetOne(dhcp6.OptionStatusCode)
+	if err != nil {
+		return nil, err
+	}
+
+	s := new(StatusCode)
+	if err := s.UnmarshalBinary(v); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
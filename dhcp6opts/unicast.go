@@ -0,0 +1,31 @@
+package dhcp6opts
+
+import (
+	"net"
+
+	"github.com/oiooj/dhcp6d"
+)
+
+// A Unicast is the IPv6 address a server grants a client permission to
+// send unicast messages to, as defined in RFC 3315, Section 22.12
+// (option code 12).  Absent this option, RFC 3315, Section 18.1.1
+// requires a client to multicast every message it sends.
+type Unicast net.IP
+
+// MarshalBinary allocates a byte slice containing the data from a
+// Unicast address.
+func (u Unicast) MarshalBinary() ([]byte, error) {
+	return net.IP(u).To16(), nil
+}
+
+// UnmarshalBinary unmarshals a raw byte slice into a Unicast address.
+func (u *Unicast) UnmarshalBinary(b []byte) error {
+	if len(b) != 16 {
+		return dhcp6.ErrInvalidOptionVal
+	}
+
+	ip := make(net.IP, 16)
+	copy(ip, b)
+	*u = Unicast(ip)
+	return nil
+}
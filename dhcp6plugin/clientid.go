@@ -0,0 +1,63 @@
+package dhcp6plugin
+
+import (
+	"net"
+
+	"github.com/oiooj/dhcp6d"
+	"github.com/oiooj/dhcp6d/dhcp6opts"
+	"github.com/oiooj/dhcp6d/dhcp6server"
+)
+
+// MetadataDUID is the Request.Metadata key under which the ClientID
+// plugin stores a client's raw DUID bytes.
+const MetadataDUID = "client_id.duid"
+
+// MetadataMAC is the Request.Metadata key under which the ClientID
+// plugin stores a client's extracted hardware address.
+const MetadataMAC = "client_id.mac"
+
+// ClientID is a Plugin which validates a request's client DUID
+// (DUID-LLT or DUID-LL), extracts the client's hardware address, and
+// stores both in the Request's Metadata for use by later Plugins.
+type ClientID struct{}
+
+// NewClientID creates a ClientID plugin.  It accepts no arguments.
+func NewClientID(_ map[string]interface{}) (dhcp6server.Plugin, error) {
+	return &ClientID{}, nil
+}
+
+// Handle implements Plugin.
+func (p *ClientID) Handle(req *dhcp6server.Request, resp dhcp6server.ResponseSender, next dhcp6server.PluginFunc) error {
+	duid, err := req.Options.GetOne(dhcp6.OptionClientID)
+	if err != nil {
+		return err
+	}
+
+	mac, err := extractMAC(duid)
+	if err != nil {
+		return err
+	}
+
+	if req.Metadata == nil {
+		req.Metadata = make(map[string]interface{})
+	}
+	req.Metadata[MetadataDUID] = duid
+	req.Metadata[MetadataMAC] = mac
+
+	return next(req, resp)
+}
+
+// extractMAC extracts a hardware address from a DUID-LLT or DUID-LL.
+func extractMAC(duid []byte) (net.HardwareAddr, error) {
+	llt := new(dhcp6opts.DUIDLLT)
+	if err := llt.UnmarshalBinary(duid); err == nil {
+		return llt.HardwareAddr, nil
+	}
+
+	ll := new(dhcp6opts.DUIDLL)
+	if err := ll.UnmarshalBinary(duid); err == nil {
+		return ll.HardwareAddr, nil
+	}
+
+	return nil, dhcp6.ErrInvalidOptionVal
+}
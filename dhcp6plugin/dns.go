@@ -0,0 +1,48 @@
+package dhcp6plugin
+
+import (
+	"net"
+
+	"github.com/oiooj/dhcp6d"
+	"github.com/oiooj/dhcp6d/dhcp6opts"
+	"github.com/oiooj/dhcp6d/dhcp6server"
+)
+
+// DNS is a Plugin which serves a fixed list of DNS recursive name
+// servers via option 23 (RFC 3646, Section 3).
+type DNS struct {
+	servers dhcp6opts.DNSServers
+}
+
+// NewDNS creates a DNS plugin from a "servers" argument: a list of IPv6
+// address strings.
+func NewDNS(args map[string]interface{}) (dhcp6server.Plugin, error) {
+	raw, _ := args["servers"].([]interface{})
+
+	servers := make(dhcp6opts.DNSServers, 0, len(raw))
+	for _, r := range raw {
+		s, ok := r.(string)
+		if !ok {
+			continue
+		}
+
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return nil, dhcp6.ErrInvalidOptionVal
+		}
+		servers = append(servers, ip)
+	}
+
+	return &DNS{servers: servers}, nil
+}
+
+// Handle implements Plugin.
+func (p *DNS) Handle(req *dhcp6server.Request, resp dhcp6server.ResponseSender, next dhcp6server.PluginFunc) error {
+	if len(p.servers) > 0 {
+		if err := resp.Options().Add(dhcp6.OptionDNSServers, p.servers); err != nil {
+			return err
+		}
+	}
+
+	return next(req, resp)
+}
@@ -0,0 +1,6 @@
+// Package dhcp6plugin provides the built-in dhcp6server.Plugin
+// implementations: client_id, server_id, preference, dns,
+// domain_search, lease, prefix_delegation, log, and send.  Registry
+// maps their configuration names to the dhcp6server.PluginFactory
+// used to construct them from a Config file.
+package dhcp6plugin
@@ -0,0 +1,41 @@
+package dhcp6plugin
+
+import (
+	"github.com/oiooj/dhcp6d"
+	"github.com/oiooj/dhcp6d/dhcp6opts"
+	"github.com/oiooj/dhcp6d/dhcp6server"
+)
+
+// DomainSearch is a Plugin which serves a fixed DNS search list via
+// option 24 (RFC 3646, Section 4).
+type DomainSearch struct {
+	domains dhcp6opts.DomainList
+}
+
+// NewDomainSearch creates a DomainSearch plugin from a "domains"
+// argument: a list of domain name strings.
+func NewDomainSearch(args map[string]interface{}) (dhcp6server.Plugin, error) {
+	raw, _ := args["domains"].([]interface{})
+
+	domains := make(dhcp6opts.DomainList, 0, len(raw))
+	for _, r := range raw {
+		s, ok := r.(string)
+		if !ok {
+			continue
+		}
+		domains = append(domains, s)
+	}
+
+	return &DomainSearch{domains: domains}, nil
+}
+
+// Handle implements Plugin.
+func (p *DomainSearch) Handle(req *dhcp6server.Request, resp dhcp6server.ResponseSender, next dhcp6server.PluginFunc) error {
+	if len(p.domains) > 0 {
+		if err := resp.Options().Add(dhcp6.OptionDomainList, p.domains); err != nil {
+			return err
+		}
+	}
+
+	return next(req, resp)
+}
@@ -0,0 +1,311 @@
+package dhcp6plugin
+
+import (
+	"net"
+	"time"
+
+	"github.com/oiooj/dhcp6d"
+	"github.com/oiooj/dhcp6d/dhcp6lease"
+	"github.com/oiooj/dhcp6d/dhcp6opts"
+	"github.com/oiooj/dhcp6d/dhcp6server"
+)
+
+// preferredLifetime and validLifetime are the lifetimes advertised for
+// every address handed out by the Lease plugin.
+const (
+	preferredLifetime = 60 * time.Second
+	validLifetime     = 90 * time.Second
+)
+
+// t1Fraction and t2Fraction are the fractions of preferredLifetime
+// used to compute an IANA's T1 and T2, as recommended in RFC 3315,
+// Section 22.4.
+const (
+	t1Fraction = 0.5
+	t2Fraction = 0.8
+)
+
+// reclaimInterval is how often a Lease plugin's Store reclaims expired
+// leases.
+const reclaimInterval = 10 * time.Second
+
+// Lease is a Plugin which assigns IPv6 addresses to clients' IA_NA
+// options using a dhcp6lease.Allocator, and handles Confirm, Decline,
+// and Release for addresses it has assigned.
+type Lease struct {
+	alloc *dhcp6lease.Allocator
+	store dhcp6lease.Store
+}
+
+// NewLease creates a Lease plugin from a "subnet" argument (a CIDR
+// string to allocate addresses from), an optional "file" argument (a
+// path to persist leases to; if unset, leases are kept in memory
+// only), and an optional "reservations" argument (a map of hardware
+// address strings to the IPv6 address string reserved for them).
+func NewLease(args map[string]interface{}) (dhcp6server.Plugin, error) {
+	subnetStr, _ := args["subnet"].(string)
+	_, subnet, err := net.ParseCIDR(subnetStr)
+	if err != nil {
+		return nil, err
+	}
+
+	reservations, err := parseReservations(args["reservations"])
+	if err != nil {
+		return nil, err
+	}
+
+	var store dhcp6lease.Store
+	if path, _ := args["file"].(string); path != "" {
+		store, err = dhcp6lease.NewFileStore(path, subnet, reclaimInterval)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		store = dhcp6lease.NewMemoryStore(reclaimInterval)
+	}
+
+	return &Lease{
+		alloc: dhcp6lease.NewAllocator(subnet, store, reservations),
+		store: store,
+	}, nil
+}
+
+// parseReservations parses a "reservations" argument into a map of
+// canonical hardware address strings to reserved IPv6 addresses.
+func parseReservations(v interface{}) (map[string]net.IP, error) {
+	raw := stringKeyedMap(v)
+
+	reservations := make(map[string]net.IP, len(raw))
+	for macStr, ipVal := range raw {
+		mac, err := net.ParseMAC(macStr)
+		if err != nil {
+			return nil, err
+		}
+
+		ipStr, _ := ipVal.(string)
+		ip := net.ParseIP(ipStr)
+		if ip == nil {
+			return nil, dhcp6.ErrInvalidOptionVal
+		}
+
+		reservations[mac.String()] = ip
+	}
+
+	return reservations, nil
+}
+
+// stringKeyedMap normalizes a nested config value into a
+// map[string]interface{}, accepting both the map[string]interface{}
+// shape BurntSushi/toml decodes tables into and the
+// map[interface{}]interface{} shape gopkg.in/yaml.v2 decodes untyped
+// mappings into, so a "reservations" argument parses the same from
+// either format.
+func stringKeyedMap(v interface{}) map[string]interface{} {
+	switch m := v.(type) {
+	case map[string]interface{}:
+		return m
+	case map[interface{}]interface{}:
+		out := make(map[string]interface{}, len(m))
+		for k, val := range m {
+			if ks, ok := k.(string); ok {
+				out[ks] = val
+			}
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
+// Handle implements Plugin.
+func (p *Lease) Handle(req *dhcp6server.Request, resp dhcp6server.ResponseSender, next dhcp6server.PluginFunc) error {
+	duid, _ := req.Metadata[MetadataDUID].([]byte)
+	if duid == nil {
+		return next(req, resp)
+	}
+	mac, _ := req.Metadata[MetadataMAC].(net.HardwareAddr)
+
+	switch req.MessageType {
+	case dhcp6.MessageTypeRelease:
+		return p.handleRelease(duid, req, resp, next)
+	case dhcp6.MessageTypeDecline:
+		return p.handleDecline(duid, req, resp, next)
+	case dhcp6.MessageTypeConfirm:
+		return p.handleConfirm(req, resp, next)
+	case dhcp6.MessageTypeSolicit:
+		return p.handleSolicit(duid, mac, req, resp, next)
+	default:
+		return p.handleAssign(duid, mac, req, resp, next)
+	}
+}
+
+// handleAssign allocates (or renews) an address for every IANA in req,
+// adds the result to resp, and -- since every message type handled
+// here is answered with a Reply -- hands the client its Reconfigure
+// key.
+func (p *Lease) handleAssign(duid []byte, mac net.HardwareAddr, req *dhcp6server.Request, resp dhcp6server.ResponseSender, next dhcp6server.PluginFunc) error {
+	auth, err := p.assign(duid, mac, req, resp)
+	if err != nil {
+		return err
+	}
+	if auth != nil {
+		if err := resp.Options().Add(dhcp6.OptionAuth, auth); err != nil {
+			return err
+		}
+	}
+
+	return next(req, resp)
+}
+
+// handleSolicit allocates an address for every IANA in req, as
+// handleAssign does, then -- if req.RapidCommit and the client sent
+// OptionRapidCommit -- commits the lease immediately by echoing the
+// option and sending a Reply in place of the usual Advertise, skipping
+// the Advertise/Request exchange (RFC 3315, Section 17.1.1).  Since
+// this short-circuits the rest of the Chain, Lease belongs after any
+// Plugin -- such as PrefixDelegation -- whose options must still reach
+// a rapid-committed Reply.
+func (p *Lease) handleSolicit(duid []byte, mac net.HardwareAddr, req *dhcp6server.Request, resp dhcp6server.ResponseSender, next dhcp6server.PluginFunc) error {
+	auth, err := p.assign(duid, mac, req, resp)
+	if err != nil {
+		return err
+	}
+
+	if !req.RapidCommit {
+		return next(req, resp)
+	}
+	if _, err := req.Options.GetOne(dhcp6.OptionRapidCommit); err != nil {
+		return next(req, resp)
+	}
+
+	if auth != nil {
+		if err := resp.Options().Add(dhcp6.OptionAuth, auth); err != nil {
+			return err
+		}
+	}
+
+	resp.Options().AddRaw(dhcp6.OptionRapidCommit, nil)
+	_, err = resp.Send(dhcp6.MessageTypeReply)
+	return err
+}
+
+// assign allocates (or renews) an address for every IANA in req via
+// p.alloc, and adds the result to resp along with its T1/T2. It
+// returns the client's Reconfigure key as an OptionAuth value, or nil
+// if req contained no IANA options, leaving it to the caller to add
+// the key to resp only once a Reply is about to be sent -- RFC 3315,
+// Section 21.5 forbids sending it in any other message, including the
+// Advertise that follows a non-rapid-commit Solicit.
+func (p *Lease) assign(duid []byte, mac net.HardwareAddr, req *dhcp6server.Request, resp dhcp6server.ResponseSender) (*dhcp6.ReconfigureKeyAuth, error) {
+	ianas, err := dhcp6opts.GetIANA(req.Options)
+	if err == dhcp6.ErrOptionNotPresent {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var key [16]byte
+	for i, ia := range ianas {
+		l, err := p.alloc.Allocate(duid, ia.IAID, mac, preferredLifetime, validLifetime, "")
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			key = l.ReconfigureKey
+		}
+
+		iaaddr, err := dhcp6opts.NewIAAddr(l.IP, l.Preferred, l.Valid, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		ia.Options = make(dhcp6.Options)
+		if err := ia.Options.Add(dhcp6.OptionIAAddr, iaaddr); err != nil {
+			return nil, err
+		}
+		ia.T1 = time.Duration(float64(l.Preferred) * t1Fraction)
+		ia.T2 = time.Duration(float64(l.Preferred) * t2Fraction)
+
+		if err := resp.Options().Add(dhcp6.OptionIANA, ia); err != nil {
+			return nil, err
+		}
+	}
+
+	return &dhcp6.ReconfigureKeyAuth{
+		KeyType: dhcp6.ReconfigureKeyTypeValue,
+		Value:   key,
+	}, nil
+}
+
+// handleRelease frees every IANA in req from p's Store.
+func (p *Lease) handleRelease(duid []byte, req *dhcp6server.Request, resp dhcp6server.ResponseSender, next dhcp6server.PluginFunc) error {
+	ianas, err := dhcp6opts.GetIANA(req.Options)
+	if err != nil {
+		return next(req, resp)
+	}
+
+	for _, ia := range ianas {
+		if err := p.store.Delete(duid, ia.IAID); err != nil {
+			return err
+		}
+	}
+
+	return next(req, resp)
+}
+
+// handleDecline frees every IANA's address in req from p's Store and
+// marks it unusable in p.alloc, as required by RFC 3315, Section
+// 18.1.7: a declined address must never be handed out again, to this
+// client or any other.
+func (p *Lease) handleDecline(duid []byte, req *dhcp6server.Request, resp dhcp6server.ResponseSender, next dhcp6server.PluginFunc) error {
+	ianas, err := dhcp6opts.GetIANA(req.Options)
+	if err != nil {
+		return next(req, resp)
+	}
+
+	for _, ia := range ianas {
+		if l, ok, err := p.store.Get(duid, ia.IAID); err == nil && ok {
+			p.alloc.Decline(l.IP)
+		}
+		if err := p.store.Delete(duid, ia.IAID); err != nil {
+			return err
+		}
+	}
+
+	return next(req, resp)
+}
+
+// handleConfirm validates, without allocating, whether every address
+// in req's IANA options is still appropriate for the link p.alloc
+// serves, and adds a status code to resp as required by RFC 3315,
+// Section 18.2.2: Success if every address is on-link, NotOnLink if
+// any is not. A Confirm never mints a new binding.
+func (p *Lease) handleConfirm(req *dhcp6server.Request, resp dhcp6server.ResponseSender, next dhcp6server.PluginFunc) error {
+	ianas, err := dhcp6opts.GetIANA(req.Options)
+	if err == dhcp6.ErrOptionNotPresent {
+		return next(req, resp)
+	}
+	if err != nil {
+		return err
+	}
+
+	status := dhcp6opts.NewStatusCode(dhcp6opts.StatusSuccess, "")
+	for _, ia := range ianas {
+		addrs, err := dhcp6opts.GetIAAddr(ia.Options)
+		if err != nil && err != dhcp6.ErrOptionNotPresent {
+			return err
+		}
+		for _, a := range addrs {
+			if !p.alloc.OnLink(a.IP) {
+				status = dhcp6opts.NewStatusCode(dhcp6opts.StatusNotOnLink, "address not on link")
+			}
+		}
+	}
+
+	if err := resp.Options().Add(dhcp6.OptionStatusCode, status); err != nil {
+		return err
+	}
+
+	return next(req, resp)
+}
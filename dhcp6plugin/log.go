@@ -0,0 +1,29 @@
+package dhcp6plugin
+
+import (
+	"encoding/hex"
+	"log"
+
+	"github.com/oiooj/dhcp6d/dhcp6server"
+)
+
+// Log is a Plugin which logs a one-line summary of every Request it
+// sees, then continues the Chain unconditionally.
+type Log struct{}
+
+// NewLog creates a Log plugin.  It accepts no arguments.
+func NewLog(_ map[string]interface{}) (dhcp6server.Plugin, error) {
+	return &Log{}, nil
+}
+
+// Handle implements Plugin.
+func (p *Log) Handle(req *dhcp6server.Request, resp dhcp6server.ResponseSender, next dhcp6server.PluginFunc) error {
+	log.Printf("%s from %s (tx %s, %d bytes)",
+		req.MessageType,
+		req.RemoteAddr,
+		hex.EncodeToString(req.TransactionID[:]),
+		req.Length,
+	)
+
+	return next(req, resp)
+}
@@ -0,0 +1,35 @@
+package dhcp6plugin
+
+import (
+	"github.com/oiooj/dhcp6d"
+	"github.com/oiooj/dhcp6d/dhcp6opts"
+	"github.com/oiooj/dhcp6d/dhcp6server"
+)
+
+// Preference is a Plugin which advertises a fixed Preference option
+// value (RFC 3315, Section 22.8) to soliciting clients.
+type Preference struct {
+	value dhcp6opts.Preference
+}
+
+// NewPreference creates a Preference plugin from a "value" argument in
+// the range 0-255.  It defaults to 0 if unset.
+func NewPreference(args map[string]interface{}) (dhcp6server.Plugin, error) {
+	// TOML decodes integers as int64, YAML as int.
+	var v int64
+	switch n := args["value"].(type) {
+	case int64:
+		v = n
+	case int:
+		v = int64(n)
+	}
+	return &Preference{value: dhcp6opts.Preference(v)}, nil
+}
+
+// Handle implements Plugin.
+func (p *Preference) Handle(req *dhcp6server.Request, resp dhcp6server.ResponseSender, next dhcp6server.PluginFunc) error {
+	if err := resp.Options().Add(dhcp6.OptionPreference, p.value); err != nil {
+		return err
+	}
+	return next(req, resp)
+}
@@ -0,0 +1,285 @@
+package dhcp6plugin
+
+import (
+	"encoding/hex"
+	"math/big"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/oiooj/dhcp6d"
+	"github.com/oiooj/dhcp6d/dhcp6opts"
+	"github.com/oiooj/dhcp6d/dhcp6server"
+)
+
+// leaseKey uniquely identifies a client's IA within a PrefixBackend.
+func leaseKey(duid []byte, iaid [4]byte) string {
+	return hex.EncodeToString(duid) + "/" + hex.EncodeToString(iaid[:])
+}
+
+// pdPreferredLifetime and pdValidLifetime are the lifetimes advertised
+// for every prefix delegated by the PrefixDelegation plugin.
+const (
+	pdPreferredLifetime = 60 * time.Second
+	pdValidLifetime     = 90 * time.Second
+)
+
+// A PrefixBackend allocates and releases delegated IPv6 prefixes for
+// clients identified by a DUID and IAID, for use by the
+// PrefixDelegation plugin.
+type PrefixBackend interface {
+	// Allocate returns the prefix and prefix length delegated to the
+	// client identified by duid and iaid, delegating a new one from the
+	// backend's pool if none is assigned yet.  If the pool is
+	// exhausted, dhcp6.ErrInvalidOptionVal is returned.
+	Allocate(duid []byte, iaid [4]byte) (net.IP, uint8, error)
+
+	// Release frees any prefix delegated to the client identified by
+	// duid and iaid.
+	Release(duid []byte, iaid [4]byte) error
+
+	// OnLink reports whether prefix falls within the backend's pool,
+	// for use answering a Confirm (RFC 3315, Section 18.2.2).
+	OnLink(prefix net.IP) bool
+}
+
+// PrefixPool is a PrefixBackend which carves fixed-length prefixes out
+// of a larger pool, in memory only, allocating them sequentially.
+type PrefixPool struct {
+	pool         *net.IPNet
+	prefixLength uint8
+	step         *big.Int
+
+	mu      sync.Mutex
+	leases  map[string]net.IP
+	inUse   map[string]bool
+	next    *big.Int
+	poolEnd *big.Int
+}
+
+// NewPrefixPool creates a PrefixPool which delegates prefixLength-bit
+// prefixes out of pool.
+func NewPrefixPool(pool *net.IPNet, prefixLength uint8) (*PrefixPool, error) {
+	ones, bits := pool.Mask.Size()
+	if bits != 128 || int(prefixLength) < ones || prefixLength > 128 {
+		return nil, dhcp6.ErrInvalidOptionVal
+	}
+
+	start := new(big.Int).SetBytes(pool.IP.Mask(pool.Mask).To16())
+	step := new(big.Int).Lsh(big.NewInt(1), uint(128-prefixLength))
+
+	size := new(big.Int).Lsh(big.NewInt(1), uint(128-ones))
+	end := new(big.Int).Add(start, size)
+
+	return &PrefixPool{
+		pool:         pool,
+		prefixLength: prefixLength,
+		step:         step,
+		leases:       make(map[string]net.IP),
+		inUse:        make(map[string]bool),
+		next:         start,
+		poolEnd:      end,
+	}, nil
+}
+
+// Allocate implements PrefixBackend.
+func (p *PrefixPool) Allocate(duid []byte, iaid [4]byte) (net.IP, uint8, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := leaseKey(duid, iaid)
+	if ip, ok := p.leases[key]; ok {
+		return ip, p.prefixLength, nil
+	}
+
+	for p.next.Cmp(p.poolEnd) < 0 {
+		ip := bigIntToIP(p.next)
+		p.next = new(big.Int).Add(p.next, p.step)
+
+		if p.inUse[ip.String()] {
+			continue
+		}
+
+		p.leases[key] = ip
+		p.inUse[ip.String()] = true
+		return ip, p.prefixLength, nil
+	}
+
+	return nil, 0, dhcp6.ErrInvalidOptionVal
+}
+
+// Release implements PrefixBackend.
+func (p *PrefixPool) Release(duid []byte, iaid [4]byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := leaseKey(duid, iaid)
+	if ip, ok := p.leases[key]; ok {
+		delete(p.inUse, ip.String())
+		delete(p.leases, key)
+
+		// Move the cursor back so the freed prefix is handed out again.
+		if n := new(big.Int).SetBytes(ip.To16()); n.Cmp(p.next) < 0 {
+			p.next = n
+		}
+	}
+	return nil
+}
+
+// OnLink implements PrefixBackend.
+func (p *PrefixPool) OnLink(prefix net.IP) bool {
+	return p.pool.Contains(prefix)
+}
+
+// bigIntToIP renders i as a 16-byte IPv6 address.
+func bigIntToIP(i *big.Int) net.IP {
+	b := i.Bytes()
+	ip := make(net.IP, 16)
+	copy(ip[16-len(b):], b)
+	return ip
+}
+
+// PrefixDelegation is a Plugin which delegates IPv6 prefixes to
+// clients' IA_PD options using a PrefixBackend, as defined in RFC 3633.
+// It handles Solicit, Request, Renew, Rebind, Confirm, and Release,
+// independently of any IA_NA handling done by the Lease plugin. RFC
+// 3633 defines no Decline for delegated prefixes.
+type PrefixDelegation struct {
+	backend PrefixBackend
+}
+
+// NewPrefixDelegation creates a PrefixDelegation plugin from a "pool"
+// argument (a CIDR string to delegate prefixes from) and a
+// "prefix_length" argument (the bit length of each delegated prefix,
+// e.g. 64).
+func NewPrefixDelegation(args map[string]interface{}) (dhcp6server.Plugin, error) {
+	poolStr, _ := args["pool"].(string)
+	_, pool, err := net.ParseCIDR(poolStr)
+	if err != nil {
+		return nil, err
+	}
+
+	// TOML decodes integers as int64, YAML as int.
+	var length int64
+	switch n := args["prefix_length"].(type) {
+	case int64:
+		length = n
+	case int:
+		length = int64(n)
+	}
+	if length == 0 {
+		length = 64
+	}
+
+	backend, err := NewPrefixPool(pool, uint8(length))
+	if err != nil {
+		return nil, err
+	}
+
+	return &PrefixDelegation{backend: backend}, nil
+}
+
+// Handle implements Plugin.
+func (p *PrefixDelegation) Handle(req *dhcp6server.Request, resp dhcp6server.ResponseSender, next dhcp6server.PluginFunc) error {
+	duid, _ := req.Metadata[MetadataDUID].([]byte)
+	if duid == nil {
+		return next(req, resp)
+	}
+
+	switch req.MessageType {
+	case dhcp6.MessageTypeRelease:
+		return p.handleRelease(duid, req, resp, next)
+	case dhcp6.MessageTypeConfirm:
+		return p.handleConfirm(req, resp, next)
+	default:
+		return p.handleAssign(duid, req, resp, next)
+	}
+}
+
+// handleAssign delegates (or confirms) a prefix for every IAPD in req,
+// and adds the result to resp.
+func (p *PrefixDelegation) handleAssign(duid []byte, req *dhcp6server.Request, resp dhcp6server.ResponseSender, next dhcp6server.PluginFunc) error {
+	iapds, err := dhcp6opts.GetIAPD(req.Options)
+	if err == dhcp6.ErrOptionNotPresent {
+		return next(req, resp)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, iapd := range iapds {
+		iapd.Options = make(dhcp6.Options)
+
+		prefix, length, err := p.backend.Allocate(duid, iapd.IAID)
+		if err != nil {
+			status := dhcp6opts.NewStatusCode(dhcp6opts.StatusNoPrefixAvail, "no prefixes available")
+			if err := iapd.Options.Add(dhcp6.OptionStatusCode, status); err != nil {
+				return err
+			}
+		} else {
+			iaprefix, err := dhcp6opts.NewIAPrefix(prefix, length, pdPreferredLifetime, pdValidLifetime, nil)
+			if err != nil {
+				return err
+			}
+			if err := iapd.Options.Add(dhcp6.OptionIAPrefix, iaprefix); err != nil {
+				return err
+			}
+		}
+
+		if err := resp.Options().Add(dhcp6.OptionIAPD, iapd); err != nil {
+			return err
+		}
+	}
+
+	return next(req, resp)
+}
+
+// handleConfirm validates, without delegating, whether every prefix in
+// req's IAPD options is still appropriate for the link p.backend
+// serves, and adds a status code to resp as required by RFC 3315,
+// Section 18.2.2: Success if every prefix is on-link, NotOnLink if any
+// is not. A Confirm never delegates a new prefix.
+func (p *PrefixDelegation) handleConfirm(req *dhcp6server.Request, resp dhcp6server.ResponseSender, next dhcp6server.PluginFunc) error {
+	iapds, err := dhcp6opts.GetIAPD(req.Options)
+	if err == dhcp6.ErrOptionNotPresent {
+		return next(req, resp)
+	}
+	if err != nil {
+		return err
+	}
+
+	status := dhcp6opts.NewStatusCode(dhcp6opts.StatusSuccess, "")
+	for _, iapd := range iapds {
+		prefixes, err := dhcp6opts.GetIAPrefix(iapd.Options)
+		if err != nil && err != dhcp6.ErrOptionNotPresent {
+			return err
+		}
+		for _, prefix := range prefixes {
+			if !p.backend.OnLink(prefix.Prefix) {
+				status = dhcp6opts.NewStatusCode(dhcp6opts.StatusNotOnLink, "prefix not on link")
+			}
+		}
+	}
+
+	if err := resp.Options().Add(dhcp6.OptionStatusCode, status); err != nil {
+		return err
+	}
+
+	return next(req, resp)
+}
+
+// handleRelease frees every IAPD in req from p's backend.
+func (p *PrefixDelegation) handleRelease(duid []byte, req *dhcp6server.Request, resp dhcp6server.ResponseSender, next dhcp6server.PluginFunc) error {
+	iapds, err := dhcp6opts.GetIAPD(req.Options)
+	if err != nil {
+		return next(req, resp)
+	}
+
+	for _, iapd := range iapds {
+		if err := p.backend.Release(duid, iapd.IAID); err != nil {
+			return err
+		}
+	}
+
+	return next(req, resp)
+}
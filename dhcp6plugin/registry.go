@@ -0,0 +1,17 @@
+package dhcp6plugin
+
+import "github.com/oiooj/dhcp6d/dhcp6server"
+
+// Registry maps the built-in plugin names used in a Config file to the
+// dhcp6server.PluginFactory used to construct them.
+var Registry = dhcp6server.PluginRegistry{
+	"client_id":         NewClientID,
+	"server_id":         NewServerID,
+	"preference":        NewPreference,
+	"dns":               NewDNS,
+	"domain_search":     NewDomainSearch,
+	"lease":             NewLease,
+	"prefix_delegation": NewPrefixDelegation,
+	"log":               NewLog,
+	"send":              NewSend,
+}
@@ -0,0 +1,33 @@
+package dhcp6plugin
+
+import (
+	"github.com/oiooj/dhcp6d"
+	"github.com/oiooj/dhcp6d/dhcp6server"
+)
+
+// Send is a Plugin which transmits the response accumulated by earlier
+// Plugins in the Chain: an Advertise for a Solicit, or a Reply for any
+// other message type.  It belongs last in a Chain, so that every
+// Plugin ahead of it has finished adding its options first.  A Request
+// already answered via Rapid Commit never reaches it, since the Lease
+// plugin sends the Reply itself and stops the Chain.
+type Send struct{}
+
+// NewSend creates a Send plugin.  It accepts no arguments.
+func NewSend(_ map[string]interface{}) (dhcp6server.Plugin, error) {
+	return &Send{}, nil
+}
+
+// Handle implements Plugin.
+func (p *Send) Handle(req *dhcp6server.Request, resp dhcp6server.ResponseSender, next dhcp6server.PluginFunc) error {
+	mt := dhcp6.MessageTypeReply
+	if req.MessageType == dhcp6.MessageTypeSolicit {
+		mt = dhcp6.MessageTypeAdvertise
+	}
+
+	if _, err := resp.Send(mt); err != nil {
+		return err
+	}
+
+	return next(req, resp)
+}
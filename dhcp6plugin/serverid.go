@@ -0,0 +1,70 @@
+package dhcp6plugin
+
+import (
+	"net"
+	"time"
+
+	"github.com/oiooj/dhcp6d"
+	"github.com/oiooj/dhcp6d/dhcp6opts"
+	"github.com/oiooj/dhcp6d/dhcp6server"
+)
+
+// ServerID is a Plugin which attaches a Server ID option (a DUID-LLT) to
+// every response.  The DUID is generated once, at construction time,
+// from a hardware address.
+type ServerID struct {
+	duid []byte
+}
+
+// NewServerID creates a ServerID plugin, generating a DUID-LLT from the
+// hardware address of the "interface" argument, or of the first
+// non-loopback interface found on the host if it is unset.
+func NewServerID(args map[string]interface{}) (dhcp6server.Plugin, error) {
+	name, _ := args["interface"].(string)
+
+	addr, err := hardwareAddr(name)
+	if err != nil {
+		return nil, err
+	}
+
+	duid := dhcp6opts.NewDUIDLLT(1, time.Now(), addr)
+	b, err := duid.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ServerID{duid: b}, nil
+}
+
+// Handle implements Plugin.
+func (p *ServerID) Handle(req *dhcp6server.Request, resp dhcp6server.ResponseSender, next dhcp6server.PluginFunc) error {
+	resp.Options().AddRaw(dhcp6.OptionServerID, p.duid)
+	return next(req, resp)
+}
+
+// hardwareAddr returns the hardware address of the interface named
+// iface, or of the first non-loopback interface with a hardware address
+// if iface is empty.
+func hardwareAddr(iface string) (net.HardwareAddr, error) {
+	if iface != "" {
+		ifi, err := net.InterfaceByName(iface)
+		if err != nil {
+			return nil, err
+		}
+		return ifi.HardwareAddr, nil
+	}
+
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ifi := range ifaces {
+		if ifi.Flags&net.FlagLoopback != 0 || len(ifi.HardwareAddr) == 0 {
+			continue
+		}
+		return ifi.HardwareAddr, nil
+	}
+
+	return nil, dhcp6.ErrOptionNotPresent
+}
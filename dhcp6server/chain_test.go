@@ -0,0 +1,61 @@
+package dhcp6server
+
+import "testing"
+
+// recordingPlugin appends its name to order, then continues the Chain
+// unless stop is set.
+type recordingPlugin struct {
+	name  string
+	stop  bool
+	order *[]string
+}
+
+func (p *recordingPlugin) Handle(req *Request, resp ResponseSender, next PluginFunc) error {
+	*p.order = append(*p.order, p.name)
+	if p.stop {
+		return nil
+	}
+	return next(req, resp)
+}
+
+func TestChainInvokesPluginsInOrder(t *testing.T) {
+	var order []string
+	chain := NewChain(
+		&recordingPlugin{name: "a", order: &order},
+		&recordingPlugin{name: "b", order: &order},
+		&recordingPlugin{name: "c", order: &order},
+	)
+
+	chain.ServeDHCP(nil, &Request{})
+
+	want := []string{"a", "b", "c"}
+	if len(order) != len(want) {
+		t.Fatalf("order = %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("order = %v, want %v", order, want)
+		}
+	}
+}
+
+func TestChainStopsWhenPluginDoesNotCallNext(t *testing.T) {
+	var order []string
+	chain := NewChain(
+		&recordingPlugin{name: "a", order: &order},
+		&recordingPlugin{name: "b", stop: true, order: &order},
+		&recordingPlugin{name: "c", order: &order},
+	)
+
+	chain.ServeDHCP(nil, &Request{})
+
+	want := []string{"a", "b"}
+	if len(order) != len(want) {
+		t.Fatalf("order = %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("order = %v, want %v", order, want)
+		}
+	}
+}
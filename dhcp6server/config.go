@@ -0,0 +1,85 @@
+package dhcp6server
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// A PluginConfig describes a single Plugin entry in a Config: the name
+// used to look the Plugin up in a PluginRegistry, and any per-plugin
+// arguments it requires.
+type PluginConfig struct {
+	Name string                 `toml:"name" yaml:"name"`
+	Args map[string]interface{} `toml:"args" yaml:"args"`
+}
+
+// A Config describes how to build and run a dhcp6server: which
+// interface to listen on, and which Plugins to wire into the
+// request-handling Chain, in order.
+type Config struct {
+	Interface string `toml:"interface" yaml:"interface"`
+
+	// ListenAddr is decoded from the "listen" key, but Server does not
+	// read it: a Server always listens on UDP port 547 of every address.
+	ListenAddr  string         `toml:"listen" yaml:"listen"`
+	RapidCommit bool           `toml:"rapid_commit" yaml:"rapid_commit"`
+	Plugins     []PluginConfig `toml:"plugin" yaml:"plugin"`
+}
+
+// LoadConfig reads and parses a Config from a TOML or YAML file.  The
+// format is chosen by the file's extension: ".yml" or ".yaml" selects
+// YAML, anything else is parsed as TOML.
+func LoadConfig(path string) (*Config, error) {
+	cfg := new(Config)
+
+	switch filepath.Ext(path) {
+	case ".yml", ".yaml":
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := yaml.Unmarshal(b, cfg); err != nil {
+			return nil, err
+		}
+	default:
+		if _, err := toml.DecodeFile(path, cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	return cfg, nil
+}
+
+// A PluginFactory constructs a Plugin from the arguments given in its
+// PluginConfig.
+type PluginFactory func(args map[string]interface{}) (Plugin, error)
+
+// A PluginRegistry maps the plugin names used in a Config file to the
+// PluginFactory used to construct them.
+type PluginRegistry map[string]PluginFactory
+
+// NewChainFromConfig builds a Chain by looking up each of cfg's
+// PluginConfig entries by name in registry, constructing it with its
+// Args, and appending it to the Chain in order.
+func NewChainFromConfig(cfg *Config, registry PluginRegistry) (*Chain, error) {
+	plugins := make([]Plugin, 0, len(cfg.Plugins))
+	for _, pc := range cfg.Plugins {
+		factory, ok := registry[pc.Name]
+		if !ok {
+			return nil, fmt.Errorf("dhcp6server: unknown plugin %q", pc.Name)
+		}
+
+		p, err := factory(pc.Args)
+		if err != nil {
+			return nil, fmt.Errorf("dhcp6server: plugin %q: %v", pc.Name, err)
+		}
+
+		plugins = append(plugins, p)
+	}
+
+	return NewChain(plugins...), nil
+}
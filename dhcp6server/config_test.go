@@ -0,0 +1,75 @@
+package dhcp6server
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testTOMLConfig = `
+interface = "eth0"
+listen = "[::]:547"
+
+[[plugin]]
+name = "log"
+
+[[plugin]]
+name = "preference"
+  [plugin.args]
+  value = 255
+`
+
+const testYAMLConfig = `
+interface: eth0
+listen: "[::]:547"
+plugin:
+  - name: log
+  - name: preference
+    args:
+      value: 255
+`
+
+func TestLoadConfigTOMLAndYAMLAgree(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dhcp6server")
+	if err != nil {
+		t.Fatalf("TempDir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	tomlPath := filepath.Join(dir, "dhcp6d.toml")
+	if err := ioutil.WriteFile(tomlPath, []byte(testTOMLConfig), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	yamlPath := filepath.Join(dir, "dhcp6d.yaml")
+	if err := ioutil.WriteFile(yamlPath, []byte(testYAMLConfig), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	tomlCfg, err := LoadConfig(tomlPath)
+	if err != nil {
+		t.Fatalf("LoadConfig(toml): %v", err)
+	}
+	yamlCfg, err := LoadConfig(yamlPath)
+	if err != nil {
+		t.Fatalf("LoadConfig(yaml): %v", err)
+	}
+
+	for name, cfg := range map[string]*Config{"toml": tomlCfg, "yaml": yamlCfg} {
+		if cfg.Interface != "eth0" {
+			t.Errorf("%s: Interface = %q, want %q", name, cfg.Interface, "eth0")
+		}
+		if len(cfg.Plugins) != 2 {
+			t.Fatalf("%s: len(Plugins) = %d, want 2", name, len(cfg.Plugins))
+		}
+		if cfg.Plugins[0].Name != "log" {
+			t.Errorf("%s: Plugins[0].Name = %q, want %q", name, cfg.Plugins[0].Name, "log")
+		}
+		if cfg.Plugins[1].Name != "preference" {
+			t.Errorf("%s: Plugins[1].Name = %q, want %q", name, cfg.Plugins[1].Name, "preference")
+		}
+		if v, ok := cfg.Plugins[1].Args["value"]; !ok || v == nil {
+			t.Errorf("%s: Plugins[1].Args[\"value\"] missing", name)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package dhcp6server
+
+// A Handler processes a DHCPv6 Request, optionally sending a reply via a
+// ResponseSender.  A *Chain of Plugins satisfies this interface, and is
+// the handler most callers should use with ListenAndServe.
+type Handler interface {
+	ServeDHCP(w ResponseSender, r *Request)
+}
+
+// HandlerFunc is an adapter allowing ordinary functions to be used as a
+// Handler.
+type HandlerFunc func(w ResponseSender, r *Request)
+
+// ServeDHCP implements Handler.
+func (f HandlerFunc) ServeDHCP(w ResponseSender, r *Request) {
+	f(w, r)
+}
@@ -0,0 +1,52 @@
+package dhcp6server
+
+import "log"
+
+// A PluginFunc invokes the next Plugin in a Chain.  A Plugin calls it to
+// continue processing a Request, or omits the call to stop the Chain.
+type PluginFunc func(req *Request, resp ResponseSender) error
+
+// A Plugin is a composable unit of DHCPv6 request handling logic.  A
+// Plugin may inspect or add to a Request's Metadata and a
+// ResponseSender's Options, then call next to continue on to the
+// following Plugin in the Chain, or return without calling next to stop
+// processing.
+type Plugin interface {
+	Handle(req *Request, resp ResponseSender, next PluginFunc) error
+}
+
+// A Chain is an ordered sequence of Plugins which together form a
+// Handler.  Plugins are invoked in the order they were given to
+// NewChain; each Plugin decides whether to continue the Chain by
+// invoking the PluginFunc it is given.
+type Chain struct {
+	plugins []Plugin
+}
+
+// NewChain creates a Chain which invokes plugins in order.
+func NewChain(plugins ...Plugin) *Chain {
+	return &Chain{plugins: plugins}
+}
+
+// ServeDHCP implements Handler by running the Chain's Plugins over req.
+// Any error returned by a Plugin is logged and otherwise ignored, since
+// there is no caller left to hand it to.
+func (c *Chain) ServeDHCP(w ResponseSender, r *Request) {
+	if err := c.invoke(0, r, w); err != nil {
+		log.Println(err)
+	}
+}
+
+// invoke runs the Plugin at index i, wiring its next argument to invoke
+// the following Plugin in the Chain.
+func (c *Chain) invoke(i int, req *Request, resp ResponseSender) error {
+	if i >= len(c.plugins) {
+		return nil
+	}
+
+	next := func(req *Request, resp ResponseSender) error {
+		return c.invoke(i+1, req, resp)
+	}
+
+	return c.plugins[i].Handle(req, resp, next)
+}
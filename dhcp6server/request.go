@@ -0,0 +1,82 @@
+package dhcp6server
+
+import (
+	"net"
+
+	"github.com/oiooj/dhcp6d"
+)
+
+// A Request is a DHCPv6 request received by a Server, and passed to a
+// Handler or Plugin for processing.
+type Request struct {
+	// MessageType is the type of DHCPv6 message carried in this Request.
+	MessageType dhcp6.MessageType
+
+	// TransactionID is the transaction ID set by the client, and echoed
+	// back in any Reply sent via a ResponseSender.
+	TransactionID dhcp6.TransactionID
+
+	// Options is the set of options sent by the client in this Request.
+	Options dhcp6.Options
+
+	// Length is the length in bytes of the raw Request, prior to
+	// parsing.
+	Length int
+
+	// RemoteAddr is the address of the client which sent this Request.
+	RemoteAddr string
+
+	// RelayInfo is the chain of relay agent hops this Request was
+	// unwrapped from, ordered from the relay agent nearest the Server
+	// to the relay agent nearest the client.  It is nil if the Request
+	// was received directly from a client.
+	RelayInfo []RelayInfo
+
+	// RapidCommit reports whether the Server that received this
+	// Request is configured with RapidCommit = true, permitting a
+	// Solicit carrying OptionRapidCommit to be answered with a Reply
+	// directly, skipping the Advertise/Request exchange (RFC 3315,
+	// Section 17.1.1).
+	RapidCommit bool
+
+	// Metadata carries values computed by earlier Plugins in a Chain,
+	// keyed by a namespaced string chosen by each Plugin, so that later
+	// Plugins may make use of them without recomputing the same work.
+	Metadata map[string]interface{}
+}
+
+// A RelayInfo describes a single relay agent hop that a Request was
+// unwrapped from, as defined in RFC 3315, Section 7.
+type RelayInfo struct {
+	// HopCount is the relay agent's hop count, echoed unchanged into
+	// the corresponding Relay-Reply frame.
+	HopCount uint8
+
+	// LinkAddress is the relay agent's view of the client's link.  It
+	// is the zero address if the relay agent is attached to that link
+	// itself.
+	LinkAddress net.IP
+
+	// PeerAddress is the address of the client, or of the relay agent
+	// which sent its message to this relay agent.
+	PeerAddress net.IP
+
+	// InterfaceID is the verbatim value of the relay agent's
+	// OptionInterfaceID, if present, echoed unchanged into the
+	// corresponding Relay-Reply frame.
+	InterfaceID []byte
+}
+
+// LinkAddress returns the outermost non-zero LinkAddress in r's
+// RelayInfo chain, for use in selecting the client's subnet.  It
+// returns nil if r was not relayed, or if every relay agent in the
+// chain reported a zero link-address.
+func (r *Request) LinkAddress() net.IP {
+	for _, ri := range r.RelayInfo {
+		if len(ri.LinkAddress) > 0 && !ri.LinkAddress.IsUnspecified() {
+			return ri.LinkAddress
+		}
+	}
+
+	return nil
+}
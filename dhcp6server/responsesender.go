@@ -0,0 +1,128 @@
+package dhcp6server
+
+import (
+	"crypto/rand"
+	"net"
+
+	"github.com/oiooj/dhcp6d"
+)
+
+// A ResponseSender is used by a Handler or Plugin to build and send a
+// reply to a Request.
+type ResponseSender interface {
+	// Options returns the Options map that will be sent in the next
+	// message passed to Send.
+	Options() dhcp6.Options
+
+	// Send sends a message of the given MessageType, along with any
+	// options added via Options, to the client which issued the current
+	// Request.
+	Send(mt dhcp6.MessageType) (int, error)
+
+	// Reconfigure sends a unicast Reconfigure message to addr,
+	// instructing the client to issue a Renew or Information-request
+	// by setting msgType to dhcp6.MessageTypeRenew or
+	// dhcp6.MessageTypeInformationRequest, as defined in RFC 3315,
+	// Section 19.  The message carries the mandatory Reconfigure
+	// Message option and is authenticated with key using the
+	// Reconfigure Key Authentication Protocol (RFC 3315, Section
+	// 21.5).
+	Reconfigure(addr net.Addr, msgType dhcp6.MessageType, key [16]byte) (int, error)
+}
+
+// responseSender is the default ResponseSender implementation, used by
+// Server to reply to clients.
+type responseSender struct {
+	conn          net.PacketConn
+	addr          net.Addr
+	transactionID dhcp6.TransactionID
+	opts          dhcp6.Options
+	relayInfo     []RelayInfo
+}
+
+// Options implements ResponseSender.
+func (r *responseSender) Options() dhcp6.Options {
+	return r.opts
+}
+
+// Send implements ResponseSender.  If the Request being replied to was
+// relayed, Send re-wraps the reply in a matching chain of
+// Relay-Reply frames, from the relay agent nearest the client outward,
+// preserving each hop's HopCount and InterfaceID.
+func (r *responseSender) Send(mt dhcp6.MessageType) (int, error) {
+	p := &dhcp6.Packet{
+		MessageType:   mt,
+		TransactionID: r.transactionID,
+		Options:       r.opts,
+	}
+
+	b, err := p.MarshalBinary()
+	if err != nil {
+		return 0, err
+	}
+
+	for i := len(r.relayInfo) - 1; i >= 0; i-- {
+		ri := r.relayInfo[i]
+
+		opts := make(dhcp6.Options)
+		opts.AddRaw(dhcp6.OptionRelayMsg, b)
+		if ri.InterfaceID != nil {
+			opts.AddRaw(dhcp6.OptionInterfaceID, ri.InterfaceID)
+		}
+
+		rm := &dhcp6.RelayMessage{
+			MessageType: dhcp6.MessageTypeRelayRepl,
+			HopCount:    ri.HopCount,
+			LinkAddress: ri.LinkAddress,
+			PeerAddress: ri.PeerAddress,
+			Options:     opts,
+		}
+
+		b, err = rm.MarshalBinary()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return r.conn.WriteTo(b, r.addr)
+}
+
+// Reconfigure implements ResponseSender.
+func (r *responseSender) Reconfigure(addr net.Addr, msgType dhcp6.MessageType, key [16]byte) (int, error) {
+	var txID dhcp6.TransactionID
+	if _, err := rand.Read(txID[:]); err != nil {
+		return 0, err
+	}
+
+	opts := make(dhcp6.Options)
+	opts.AddRaw(dhcp6.OptionReconfMsg, []byte{byte(msgType)})
+	if err := opts.Add(dhcp6.OptionAuth, &dhcp6.ReconfigureKeyAuth{KeyType: dhcp6.ReconfigureKeyTypeHMAC}); err != nil {
+		return 0, err
+	}
+
+	p := &dhcp6.Packet{
+		MessageType:   dhcp6.MessageTypeReconfigure,
+		TransactionID: txID,
+		Options:       opts,
+	}
+
+	b, err := p.MarshalBinary()
+	if err != nil {
+		return 0, err
+	}
+
+	opts.Del(dhcp6.OptionAuth)
+	if err := opts.Add(dhcp6.OptionAuth, &dhcp6.ReconfigureKeyAuth{
+		KeyType: dhcp6.ReconfigureKeyTypeHMAC,
+		Value:   dhcp6.HMACReconfigureKey(key, b),
+	}); err != nil {
+		return 0, err
+	}
+
+	b, err = p.MarshalBinary()
+	if err != nil {
+		return 0, err
+	}
+
+	return r.conn.WriteTo(b, addr)
+}
@@ -0,0 +1,142 @@
+// Package dhcp6server implements a DHCPv6 server on top of the wire
+// types defined in package dhcp6.  Request handling is expressed as a
+// Chain of composable Plugins, so the server may be embedded as a
+// library and extended without modifying this package.
+package dhcp6server
+
+import (
+	"fmt"
+	"log"
+	"net"
+
+	"github.com/oiooj/dhcp6d"
+	"golang.org/x/net/ipv6"
+)
+
+// serverPort is the UDP port DHCPv6 servers listen on, as defined in
+// RFC 3315, Section 5.2.
+const serverPort = 547
+
+// allDHCPRelayAgentsAndServers is the link-local multicast address that
+// DHCPv6 clients and relay agents send requests to, as defined in RFC
+// 3315, Section 5.1.
+var allDHCPRelayAgentsAndServers = net.ParseIP("ff02::1:2")
+
+// A Server serves DHCPv6 requests received on Iface using Handler.
+type Server struct {
+	// Iface is the name of the network interface the Server listens on.
+	Iface string
+
+	// Handler serves each Request received by the Server.  A *Chain of
+	// Plugins satisfies this interface.
+	Handler Handler
+
+	// RapidCommit enables Rapid Commit (RFC 3315, Section 17.1.1): a
+	// Handler may answer a Solicit carrying OptionRapidCommit with a
+	// Reply directly, skipping the Advertise/Request exchange.  It is
+	// surfaced to each Handler via Request.RapidCommit.
+	RapidCommit bool
+}
+
+// ListenAndServe listens for DHCPv6 requests on the network interface
+// named by iface, and invokes h to handle each request it receives.
+func ListenAndServe(iface string, h Handler) error {
+	s := &Server{Iface: iface, Handler: h}
+	return s.ListenAndServe()
+}
+
+// ListenAndServe listens for DHCPv6 requests on s.Iface, and invokes
+// s.Handler to handle each request it receives.
+func (s *Server) ListenAndServe() error {
+	ifi, err := net.InterfaceByName(s.Iface)
+	if err != nil {
+		return err
+	}
+
+	conn, err := net.ListenPacket("udp6", fmt.Sprintf("[::]:%d", serverPort))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	pc := ipv6.NewPacketConn(conn)
+	if err := pc.JoinGroup(ifi, &net.UDPAddr{IP: allDHCPRelayAgentsAndServers}); err != nil {
+		return err
+	}
+
+	buf := make([]byte, 1500)
+	for {
+		n, _, addr, err := pc.ReadFrom(buf)
+		if err != nil {
+			return err
+		}
+
+		req, err := parseRequest(buf[:n], addr)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		req.RapidCommit = s.RapidCommit
+
+		resp := &responseSender{
+			conn:          conn,
+			addr:          addr,
+			transactionID: req.TransactionID,
+			opts:          make(dhcp6.Options),
+			relayInfo:     req.RelayInfo,
+		}
+
+		s.Handler.ServeDHCP(resp, req)
+	}
+}
+
+// parseRequest unmarshals a raw DHCPv6 packet received from addr into a
+// Request, unwrapping up to dhcp6.HopCountLimit nested Relay-Forward
+// frames and recording their RelayInfo along the way.
+func parseRequest(b []byte, addr net.Addr) (*Request, error) {
+	length := len(b)
+
+	var relayInfo []RelayInfo
+	for len(b) > 0 && dhcp6.MessageType(b[0]) == dhcp6.MessageTypeRelayForw {
+		if len(relayInfo) >= dhcp6.HopCountLimit {
+			return nil, dhcp6.ErrInvalidPacket
+		}
+
+		rm := new(dhcp6.RelayMessage)
+		if err := rm.UnmarshalBinary(b); err != nil {
+			return nil, err
+		}
+
+		var ifaceID []byte
+		if v, err := rm.Options.GetOne(dhcp6.OptionInterfaceID); err == nil {
+			ifaceID = v
+		}
+
+		relayInfo = append(relayInfo, RelayInfo{
+			HopCount:    rm.HopCount,
+			LinkAddress: rm.LinkAddress,
+			PeerAddress: rm.PeerAddress,
+			InterfaceID: ifaceID,
+		})
+
+		inner, err := rm.Options.GetOne(dhcp6.OptionRelayMsg)
+		if err != nil {
+			return nil, err
+		}
+		b = inner
+	}
+
+	p := new(dhcp6.Packet)
+	if err := p.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+
+	return &Request{
+		MessageType:   p.MessageType,
+		TransactionID: p.TransactionID,
+		Options:       p.Options,
+		Length:        length,
+		RemoteAddr:    addr.String(),
+		RelayInfo:     relayInfo,
+	}, nil
+}
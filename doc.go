@@ -0,0 +1,6 @@
+// Package dhcp6 implements a DHCPv6 server, as described in RFC 3315.
+//
+// The types and functions in this package are primarily meant to be used
+// by the dhcp6server and dhcp6opts subpackages, which build a usable
+// server and option set on top of the wire types defined here.
+package dhcp6
@@ -0,0 +1,19 @@
+package dhcp6
+
+import "errors"
+
+// Errors returned by the functions and methods in this package and its
+// subpackages.
+var (
+	// ErrOptionNotPresent is returned when a requested option code is not
+	// present in a DHCPv6 packet's Options map.
+	ErrOptionNotPresent = errors.New("dhcp6: option not present in options map")
+
+	// ErrInvalidOptionVal is returned when an option contains a malformed
+	// value that cannot be parsed.
+	ErrInvalidOptionVal = errors.New("dhcp6: invalid option value")
+
+	// ErrInvalidPacket is returned when a Packet is too short to contain
+	// valid DHCPv6 data.
+	ErrInvalidPacket = errors.New("dhcp6: invalid packet")
+)
@@ -0,0 +1,57 @@
+package dhcp6
+
+// A MessageType is a value used to indicate the type of a DHCPv6 message,
+// as defined in RFC 3315, Section 5.3.
+type MessageType uint8
+
+// Message types defined in RFC 3315, Section 5.3, and RelayForward/
+// RelayReply, defined in RFC 3315, Section 7.
+const (
+	MessageTypeSolicit            MessageType = 1
+	MessageTypeAdvertise          MessageType = 2
+	MessageTypeRequest            MessageType = 3
+	MessageTypeConfirm            MessageType = 4
+	MessageTypeRenew              MessageType = 5
+	MessageTypeRebind             MessageType = 6
+	MessageTypeReply              MessageType = 7
+	MessageTypeRelease            MessageType = 8
+	MessageTypeDecline            MessageType = 9
+	MessageTypeReconfigure        MessageType = 10
+	MessageTypeInformationRequest MessageType = 11
+	MessageTypeRelayForw          MessageType = 12
+	MessageTypeRelayRepl          MessageType = 13
+)
+
+// String returns the string representation of a MessageType.
+func (m MessageType) String() string {
+	switch m {
+	case MessageTypeSolicit:
+		return "solicit"
+	case MessageTypeAdvertise:
+		return "advertise"
+	case MessageTypeRequest:
+		return "request"
+	case MessageTypeConfirm:
+		return "confirm"
+	case MessageTypeRenew:
+		return "renew"
+	case MessageTypeRebind:
+		return "rebind"
+	case MessageTypeReply:
+		return "reply"
+	case MessageTypeRelease:
+		return "release"
+	case MessageTypeDecline:
+		return "decline"
+	case MessageTypeReconfigure:
+		return "reconfigure"
+	case MessageTypeInformationRequest:
+		return "information request"
+	case MessageTypeRelayForw:
+		return "relay-forward"
+	case MessageTypeRelayRepl:
+		return "relay-reply"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,89 @@
+package dhcp6
+
+// An OptionCode is a value used to indicate the type of a DHCPv6 option,
+// as defined in RFC 3315, Section 22, and subsequent RFCs which add
+// additional options.
+type OptionCode uint16
+
+// OptionCode constants used to identify options in a DHCPv6 options map,
+// as defined in RFC 3315, Section 24.3, RFC 3633, Section 10, and RFC
+// 3646, Section 4.
+const (
+	OptionClientID     OptionCode = 1
+	OptionServerID     OptionCode = 2
+	OptionIANA         OptionCode = 3
+	OptionIATA         OptionCode = 4
+	OptionIAAddr       OptionCode = 5
+	OptionORO          OptionCode = 6
+	OptionPreference   OptionCode = 7
+	OptionElapsedTime  OptionCode = 8
+	OptionRelayMsg     OptionCode = 9
+	OptionAuth         OptionCode = 11
+	OptionUnicast      OptionCode = 12
+	OptionStatusCode   OptionCode = 13
+	OptionRapidCommit  OptionCode = 14
+	OptionUserClass    OptionCode = 15
+	OptionVendorClass  OptionCode = 16
+	OptionVendorOpts   OptionCode = 17
+	OptionInterfaceID  OptionCode = 18
+	OptionReconfMsg    OptionCode = 19
+	OptionReconfAccept OptionCode = 20
+	OptionDNSServers   OptionCode = 23
+	OptionDomainList   OptionCode = 24
+	OptionIAPD         OptionCode = 25
+	OptionIAPrefix     OptionCode = 26
+)
+
+// String returns the string representation of an OptionCode.
+func (o OptionCode) String() string {
+	switch o {
+	case OptionClientID:
+		return "client ID"
+	case OptionServerID:
+		return "server ID"
+	case OptionIANA:
+		return "IA_NA"
+	case OptionIATA:
+		return "IA_TA"
+	case OptionIAAddr:
+		return "IA address"
+	case OptionORO:
+		return "option request"
+	case OptionPreference:
+		return "preference"
+	case OptionElapsedTime:
+		return "elapsed time"
+	case OptionRelayMsg:
+		return "relay message"
+	case OptionAuth:
+		return "authentication"
+	case OptionUnicast:
+		return "unicast"
+	case OptionStatusCode:
+		return "status code"
+	case OptionRapidCommit:
+		return "rapid commit"
+	case OptionUserClass:
+		return "user class"
+	case OptionVendorClass:
+		return "vendor class"
+	case OptionVendorOpts:
+		return "vendor opts"
+	case OptionInterfaceID:
+		return "interface ID"
+	case OptionReconfMsg:
+		return "reconfigure message"
+	case OptionReconfAccept:
+		return "reconfigure accept"
+	case OptionDNSServers:
+		return "DNS servers"
+	case OptionDomainList:
+		return "domain search list"
+	case OptionIAPD:
+		return "IA_PD"
+	case OptionIAPrefix:
+		return "IA prefix"
+	default:
+		return "unknown"
+	}
+}
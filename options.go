@@ -0,0 +1,149 @@
+package dhcp6
+
+import (
+	"encoding/binary"
+)
+
+// An Options map is a map of OptionCode keys to a slice of byte slice
+// values.  Its methods can be used to easily check for and parse
+// additional information from a client or server message.
+type Options map[OptionCode][][]byte
+
+// Add adds a new OptionCode key and Marshaler value to the Options map.
+func (o Options) Add(code OptionCode, value Marshaler) error {
+	b, err := value.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	o.AddRaw(code, b)
+	return nil
+}
+
+// AddRaw adds a new OptionCode key and raw value to the Options map.
+func (o Options) AddRaw(code OptionCode, value []byte) {
+	o[code] = append(o[code], value)
+}
+
+// Get attempts to retrieve all values specified by an OptionCode key.
+// If a value is found, Get returns the value as a slice of byte slices,
+// and a boolean true value.  If it is not found, Get returns nil and
+// boolean false.
+func (o Options) Get(code OptionCode) ([][]byte, bool) {
+	if o == nil {
+		return nil, false
+	}
+
+	v, ok := o[code]
+	if !ok || len(v) == 0 {
+		return nil, false
+	}
+
+	return v, true
+}
+
+// GetOne attempts to retrieve the first value specified by an OptionCode
+// key.  If a value is found, GetOne returns the value as a byte slice,
+// and a nil error.  If it is not found, GetOne returns ErrOptionNotPresent.
+func (o Options) GetOne(code OptionCode) ([]byte, error) {
+	v, ok := o.Get(code)
+	if !ok {
+		return nil, ErrOptionNotPresent
+	}
+
+	return v[0], nil
+}
+
+// Del deletes all values specified by an OptionCode key.
+func (o Options) Del(code OptionCode) {
+	delete(o, code)
+}
+
+// A Marshaler is a type which can marshal itself into binary form for use
+// in an Options map.
+type Marshaler interface {
+	MarshalBinary() ([]byte, error)
+}
+
+// An Unmarshaler is a type which can unmarshal a binary representation of
+// itself from an Options map value.
+type Unmarshaler interface {
+	UnmarshalBinary(b []byte) error
+}
+
+// MarshalBinary allocates a byte slice containing the data from an
+// Options map, in the format specified by RFC 3315, Section 22.
+//
+// Options are sorted by OptionCode value in ascending order, for
+// deterministic output.
+func (o Options) MarshalBinary() ([]byte, error) {
+	// Count number of bytes required to allocate a properly sized slice.
+	var n int
+	codes := o.sortedCodes()
+	for _, c := range codes {
+		for _, v := range o[c] {
+			// 2 bytes: option code, 2 bytes: option length, n bytes: data
+			n += 4 + len(v)
+		}
+	}
+
+	b := make([]byte, 0, n)
+	for _, c := range codes {
+		for _, v := range o[c] {
+			tmp := make([]byte, 4+len(v))
+			binary.BigEndian.PutUint16(tmp[0:2], uint16(c))
+			binary.BigEndian.PutUint16(tmp[2:4], uint16(len(v)))
+			copy(tmp[4:], v)
+			b = append(b, tmp...)
+		}
+	}
+
+	return b, nil
+}
+
+// sortedCodes returns the option codes present in the Options map, sorted
+// in ascending order.
+func (o Options) sortedCodes() []OptionCode {
+	codes := make([]OptionCode, 0, len(o))
+	for c := range o {
+		codes = append(codes, c)
+	}
+
+	for i := 1; i < len(codes); i++ {
+		for j := i; j > 0 && codes[j-1] > codes[j]; j-- {
+			codes[j-1], codes[j] = codes[j], codes[j-1]
+		}
+	}
+
+	return codes
+}
+
+// ParseOptions parses a raw byte slice into an Options map, in the format
+// specified by RFC 3315, Section 22.  It is used by message parsers in
+// this package and in the dhcp6opts subpackage, to parse options nested
+// within other options, such as IANA and IAPD.
+func ParseOptions(b []byte) (Options, error) {
+	options := make(Options)
+
+	buf := b
+	for len(buf) > 0 {
+		if len(buf) < 4 {
+			return nil, ErrInvalidPacket
+		}
+
+		code := OptionCode(binary.BigEndian.Uint16(buf[0:2]))
+		length := binary.BigEndian.Uint16(buf[2:4])
+
+		if len(buf) < int(4+length) {
+			return nil, ErrInvalidPacket
+		}
+
+		value := make([]byte, length)
+		copy(value, buf[4:4+length])
+		options.AddRaw(code, value)
+
+		buf = buf[4+length:]
+	}
+
+	return options, nil
+}
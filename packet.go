@@ -0,0 +1,48 @@
+package dhcp6
+
+// A TransactionID is a DHCPv6 transaction ID, as defined in RFC 3315,
+// Section 15.  It is a 3-byte value chosen by a client and echoed back
+// by a server so that a client may associate replies with its requests.
+type TransactionID [3]byte
+
+// A Packet is a raw, non-relayed DHCPv6 packet, as defined in RFC 3315,
+// Section 6.  It is used to carry a MessageType, TransactionID, and
+// Options between a client and a server.
+type Packet struct {
+	MessageType   MessageType
+	TransactionID TransactionID
+	Options       Options
+}
+
+// MarshalBinary allocates a byte slice containing the data from a Packet.
+func (p *Packet) MarshalBinary() ([]byte, error) {
+	opts, err := p.Options.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, 4+len(opts))
+	b[0] = byte(p.MessageType)
+	copy(b[1:4], p.TransactionID[:])
+	copy(b[4:], opts)
+
+	return b, nil
+}
+
+// UnmarshalBinary unmarshals a raw byte slice into a Packet.
+func (p *Packet) UnmarshalBinary(b []byte) error {
+	if len(b) < 4 {
+		return ErrInvalidPacket
+	}
+
+	p.MessageType = MessageType(b[0])
+	copy(p.TransactionID[:], b[1:4])
+
+	options, err := ParseOptions(b[4:])
+	if err != nil {
+		return err
+	}
+	p.Options = options
+
+	return nil
+}
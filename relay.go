@@ -0,0 +1,78 @@
+package dhcp6
+
+import "net"
+
+// HopCountLimit is the maximum number of nested Relay-Forward messages
+// a server will unwrap before giving up, as defined in RFC 3315,
+// Section 20.
+const HopCountLimit = 32
+
+// A RelayMessage is a Relay-Forward or Relay-Reply message, as defined
+// in RFC 3315, Section 7.  It carries a relay agent's view of the
+// client's link and the client (or, in a reply, the relay agent
+// itself), and a set of Options, one of which (OptionRelayMsg) carries
+// the nested client message, or a further nested RelayMessage.
+type RelayMessage struct {
+	// MessageType is MessageTypeRelayForw or MessageTypeRelayRepl.
+	MessageType MessageType
+
+	// HopCount is incremented by each relay agent that forwards this
+	// message, and echoed unchanged in the corresponding reply.
+	HopCount uint8
+
+	// LinkAddress is used by the server to identify the link the
+	// client is attached to.  It is the zero address if the relay
+	// agent is attached to that link itself.
+	LinkAddress net.IP
+
+	// PeerAddress is the address of the client, or of the relay agent
+	// which sent this message to the relay agent that produced it.
+	PeerAddress net.IP
+
+	// Options is the set of options carried by this RelayMessage.
+	Options Options
+}
+
+// MarshalBinary allocates a byte slice containing the data from a
+// RelayMessage.
+func (r *RelayMessage) MarshalBinary() ([]byte, error) {
+	opts, err := r.Options.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, 34+len(opts))
+	b[0] = byte(r.MessageType)
+	b[1] = r.HopCount
+	copy(b[2:18], r.LinkAddress.To16())
+	copy(b[18:34], r.PeerAddress.To16())
+	copy(b[34:], opts)
+
+	return b, nil
+}
+
+// UnmarshalBinary unmarshals a raw byte slice into a RelayMessage.
+func (r *RelayMessage) UnmarshalBinary(b []byte) error {
+	if len(b) < 34 {
+		return ErrInvalidPacket
+	}
+
+	r.MessageType = MessageType(b[0])
+	r.HopCount = b[1]
+
+	link := make(net.IP, 16)
+	copy(link, b[2:18])
+	r.LinkAddress = link
+
+	peer := make(net.IP, 16)
+	copy(peer, b[18:34])
+	r.PeerAddress = peer
+
+	options, err := ParseOptions(b[34:])
+	if err != nil {
+		return err
+	}
+	r.Options = options
+
+	return nil
+}
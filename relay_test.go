@@ -0,0 +1,58 @@
+package dhcp6
+
+import (
+	"bytes"
+	"net"
+	"testing"
+)
+
+func TestRelayMessageMarshalUnmarshal(t *testing.T) {
+	inner := make(Options)
+	inner.AddRaw(OptionInterfaceID, []byte("eth0"))
+
+	want := &RelayMessage{
+		MessageType: MessageTypeRelayForw,
+		HopCount:    1,
+		LinkAddress: net.ParseIP("2001:db8::1"),
+		PeerAddress: net.ParseIP("fe80::1"),
+		Options:     inner,
+	}
+
+	b, err := want.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+
+	got := new(RelayMessage)
+	if err := got.UnmarshalBinary(b); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+
+	if got.MessageType != want.MessageType {
+		t.Errorf("MessageType: got %v, want %v", got.MessageType, want.MessageType)
+	}
+	if got.HopCount != want.HopCount {
+		t.Errorf("HopCount: got %d, want %d", got.HopCount, want.HopCount)
+	}
+	if !got.LinkAddress.Equal(want.LinkAddress) {
+		t.Errorf("LinkAddress: got %v, want %v", got.LinkAddress, want.LinkAddress)
+	}
+	if !got.PeerAddress.Equal(want.PeerAddress) {
+		t.Errorf("PeerAddress: got %v, want %v", got.PeerAddress, want.PeerAddress)
+	}
+
+	gotIfaceID, err := got.Options.GetOne(OptionInterfaceID)
+	if err != nil {
+		t.Fatalf("GetOne(OptionInterfaceID): %v", err)
+	}
+	if !bytes.Equal(gotIfaceID, []byte("eth0")) {
+		t.Errorf("InterfaceID: got %q, want %q", gotIfaceID, "eth0")
+	}
+}
+
+func TestRelayMessageUnmarshalBinaryShort(t *testing.T) {
+	r := new(RelayMessage)
+	if err := r.UnmarshalBinary(make([]byte, 33)); err != ErrInvalidPacket {
+		t.Fatalf("UnmarshalBinary(33 bytes) = %v, want ErrInvalidPacket", err)
+	}
+}